@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"time"
 
 	fb "firebase.google.com/go"
@@ -14,8 +15,15 @@ import (
 	"github.com/TeddyKahwaji/spice-tunes-go/internal/gcp"
 	"github.com/TeddyKahwaji/spice-tunes-go/internal/logger"
 	"github.com/TeddyKahwaji/spice-tunes-go/internal/music"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/applemusic"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiocache"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/deezer"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/library"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/soundcloud"
 	sw "github.com/TeddyKahwaji/spice-tunes-go/pkg/spotify"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/tidal"
 	"github.com/TeddyKahwaji/spice-tunes-go/pkg/youtube"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/ytdlp"
 	"github.com/bwmarrin/discordgo"
 	"github.com/zmb3/spotify"
 	"go.uber.org/zap"
@@ -43,7 +51,7 @@ func newDiscordBotClient(token string, httpClient *http.Client) (*discordgo.Sess
 	return bot, nil
 }
 
-func newSpotifyWrapperClient(ctx context.Context, clientID string, clientSecret string) *sw.SpotifyClientWrapper {
+func newSpotifyWrapperClient(ctx context.Context, httpClient *http.Client, clientID string, clientSecret string) *sw.SpotifyClientWrapper {
 	config := &clientcredentials.Config{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
@@ -53,7 +61,7 @@ func newSpotifyWrapperClient(ctx context.Context, clientID string, clientSecret
 	tokenClient := config.Client(ctx)
 	client := spotify.NewClient(tokenClient)
 
-	return sw.NewSpotifyClientWrapper(&client)
+	return sw.NewSpotifyClientWrapper(&client, tokenClient, httpClient)
 }
 
 func newFirebaseClient(ctx context.Context, projectID string) (*firebase.Client, error) {
@@ -75,10 +83,98 @@ func newFirebaseClient(ctx context.Context, projectID string) (*firebase.Client,
 	return firebase.NewClient(fsClient), nil
 }
 
+// newAudioCache returns nil when no cache directory is configured, which disables caching.
+func newAudioCache(dir string, sizeMB string) (*audiocache.Cache, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	const defaultAudioCacheSizeMB = 2048
+
+	maxMB := int64(defaultAudioCacheSizeMB)
+	if sizeMB != "" {
+		parsed, err := strconv.ParseInt(sizeMB, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing audio cache size: %w", err)
+		}
+
+		maxMB = parsed
+	}
+
+	cache, err := audiocache.New(dir, maxMB*1024*1024)
+	if err != nil {
+		return nil, fmt.Errorf("creating audio cache: %w", err)
+	}
+
+	return cache, nil
+}
+
+// newMusicLibrary returns nil when no library directory is configured. The library is
+// indexed in the background and rescanned periodically to pick up changes.
+func newMusicLibrary(dir string, logger *zap.Logger) (*library.Library, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	const libraryScanInterval = time.Minute
+
+	musicLibrary, err := library.New(dir)
+	if err != nil {
+		return nil, fmt.Errorf("creating music library: %w", err)
+	}
+
+	go func() {
+		ctx := context.Background()
+
+		if err := musicLibrary.Scan(ctx); err != nil {
+			logger.Warn("unable to scan music library", zap.Error(err))
+		}
+
+		logger.Info("music library indexed", zap.Int("tracks", musicLibrary.Len()))
+
+		musicLibrary.Watch(ctx, libraryScanInterval, func(err error) {
+			logger.Warn("unable to rescan music library", zap.Error(err))
+		})
+	}()
+
+	return musicLibrary, nil
+}
+
+// newYoutubeRetriever returns the yt-dlp backed retriever for the "ytdlp" source, which
+// needs no Data API credentials, and the Data API backed one otherwise.
+func newYoutubeRetriever(ctx context.Context, httpClient *http.Client, source string) (music.YoutubeRetriever, error) {
+	switch source {
+	case "ytdlp":
+		return youtube.NewDLPWrapper(httpClient), nil
+	case "", "api":
+	default:
+		return nil, fmt.Errorf("unsupported youtube source %q", source)
+	}
+
+	creds, err := gcp.GetCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("getting gcp credentials: %w", err)
+	}
+
+	youtubeSearchWrapper, err := youtube.NewYoutubeSearchWrapper(ctx, creds, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("creating youtube search wrapper: %w", err)
+	}
+
+	return youtubeSearchWrapper, nil
+}
+
 func main() {
 	discordToken := os.Getenv("DISCORD_TOKEN")
 	clientID := os.Getenv("SPOTIFY_CLIENT_ID")
 	clientSecret := os.Getenv("SPOTIFY_CLIENT_SECRET")
+	downloadMode := os.Getenv("DOWNLOAD_MODE")
+	audioCacheDir := os.Getenv("AUDIO_CACHE_DIR")
+	audioCacheSizeMB := os.Getenv("AUDIO_CACHE_SIZE_MB")
+	allowedExtractors := os.Getenv("YTDLP_ALLOWED_EXTRACTORS")
+	deniedExtractors := os.Getenv("YTDLP_DENIED_EXTRACTORS")
+	libraryDir := os.Getenv("LIBRARY_DIR")
+	youtubeSource := os.Getenv("YOUTUBE_SOURCE")
 
 	logger := logger.NewLogger()
 	defer func() {
@@ -106,18 +202,24 @@ func main() {
 		},
 	}
 
-	const gcpProjectID = "dj-bot-46e53"
-	creds, err := gcp.GetCredentials()
+	audioCache, err := newAudioCache(audioCacheDir, audioCacheSizeMB)
+	if err != nil {
+		logger.Fatal("unable to instantiate audio cache", zap.Error(err))
+	}
+
+	musicLibrary, err := newMusicLibrary(libraryDir, logger)
 	if err != nil {
-		logger.Fatal("unable to retrieve gcp credentials", zap.Error(err))
+		logger.Fatal("unable to instantiate music library", zap.Error(err))
 	}
 
+	const gcpProjectID = "dj-bot-46e53"
+
 	bot.AddHandler(func(session *discordgo.Session, _ *discordgo.Ready) {
 		ctx := context.Background()
 
-		spotifyWrapper := newSpotifyWrapperClient(ctx, clientID, clientSecret)
+		spotifyWrapper := newSpotifyWrapperClient(ctx, &httpClient, clientID, clientSecret)
 
-		youtubeSearchWrapper, err := youtube.NewYoutubeSearchWrapper(ctx, creds)
+		youtubeSearchWrapper, err := newYoutubeRetriever(ctx, &httpClient, youtubeSource)
 		if err != nil {
 			logger.Fatal("unable to instantiate youtubeWrapperClient", zap.Error(err))
 		}
@@ -134,6 +236,17 @@ func main() {
 			SpotifyWrapper:       spotifyWrapper,
 			Logger:               logger,
 			YoutubeSearchWrapper: youtubeSearchWrapper,
+			SoundCloudWrapper:    soundcloud.NewSoundCloudWrapper(&httpClient),
+			AppleMusicWrapper:    applemusic.NewAppleMusicWrapper(&httpClient),
+			DeezerWrapper:        deezer.NewDeezerWrapper(&httpClient),
+			TidalWrapper:         tidal.NewTidalWrapper(&httpClient),
+			GenericWrapper: ytdlp.NewGenericWrapper(&httpClient, ytdlp.ExtractorFilter{
+				Allow: ytdlp.ParseExtractorList(allowedExtractors),
+				Deny:  ytdlp.ParseExtractorList(deniedExtractors),
+			}),
+			DownloadMode: music.DownloadMode(downloadMode),
+			AudioCache:   audioCache,
+			Library:      musicLibrary,
 		})
 		if err != nil {
 			logger.Fatal("unable to instantiate music cog", zap.Error(err))
@@ -167,6 +280,19 @@ func main() {
 		}
 	}()
 
+	if audioCache != nil {
+		defer func() {
+			stats := audioCache.Stats()
+			logger.Info("audio cache usage",
+				zap.Int64("hits", stats.Hits),
+				zap.Int64("misses", stats.Misses),
+				zap.Float64("hit_rate", stats.HitRate()),
+				zap.Int("cached_tracks", stats.Entries),
+				zap.Int64("cached_bytes", stats.Bytes),
+			)
+		}()
+	}
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
 	<-stop
@@ -7,7 +7,10 @@ type MusicPlayButtonsConfig struct {
 	BackDisabled    bool
 	SkipDisabled    bool
 	ClearDisabled   bool
+	SeekDisabled    bool
 	Resume          bool
+	LoopLabel       string
+	LoopActive      bool
 }
 
 func GetMusicPlayerButtons(config MusicPlayButtonsConfig) []discordgo.MessageComponent {
@@ -26,6 +29,19 @@ func GetMusicPlayerButtons(config MusicPlayButtonsConfig) []discordgo.MessageCom
 		pauseResumeBtn.Emoji.Name = "▶️"
 	}
 
+	loopBtn := discordgo.Button{
+		CustomID: "LoopBtn",
+		Label:    config.LoopLabel,
+		Style:    discordgo.SecondaryButton,
+		Emoji: &discordgo.ComponentEmoji{
+			Name: "🔁", // Repeat emoji
+		},
+	}
+
+	if config.LoopActive {
+		loopBtn.Style = discordgo.SuccessButton
+	}
+
 	return []discordgo.MessageComponent{
 		discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
@@ -67,5 +83,28 @@ func GetMusicPlayerButtons(config MusicPlayButtonsConfig) []discordgo.MessageCom
 				},
 			},
 		},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Disabled: config.SeekDisabled,
+					CustomID: "SeekBackBtn",
+					Label:    "-15s",
+					Style:    discordgo.SecondaryButton,
+					Emoji: &discordgo.ComponentEmoji{
+						Name: "⏪", // Fast reverse emoji
+					},
+				},
+				discordgo.Button{
+					Disabled: config.SeekDisabled,
+					CustomID: "SeekForwardBtn",
+					Label:    "+15s",
+					Style:    discordgo.SecondaryButton,
+					Emoji: &discordgo.ComponentEmoji{
+						Name: "⏩", // Fast forward emoji
+					},
+				},
+				loopBtn,
+			},
+		},
 	}
 }
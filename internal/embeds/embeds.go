@@ -8,6 +8,7 @@ import (
 
 	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
 	"github.com/TeddyKahwaji/spice-tunes-go/pkg/funcs"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/podcast"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -92,13 +93,23 @@ func NoLikedTracksFound(member *discordgo.User) *discordgo.MessageEmbed {
 	}
 }
 
+// Thumbnail returns nil for tracks without artwork, since discord rejects
+// thumbnails with an empty url.
+func Thumbnail(url string) *discordgo.MessageEmbedThumbnail {
+	if url == "" {
+		return nil
+	}
+
+	return &discordgo.MessageEmbedThumbnail{
+		URL: url,
+	}
+}
+
 func LikedSongEmbed(track *audiotype.TrackData) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
-		Title: "👍 Like Recorded",
-		Color: Blurple,
-		Thumbnail: &discordgo.MessageEmbedThumbnail{
-			URL: track.TrackImageURL,
-		},
+		Title:     "👍 Like Recorded",
+		Color:     Blurple,
+		Thumbnail: Thumbnail(track.TrackImageURL),
 		Fields: []*discordgo.MessageEmbedField{
 			{
 				Value: fmt.Sprintf("I've recorded that you liked `%s`", track.TrackName),
@@ -156,6 +167,40 @@ func TracksSwappedEmbed(member *discordgo.Member, firstTrack *audiotype.TrackDat
 	}
 }
 
+// RemovedTracksEmbed summarises a bulk removal from the queue, listing the first of the removed tracks.
+func RemovedTracksEmbed(member *discordgo.Member, tracks []*audiotype.TrackData, reason string) *discordgo.MessageEmbed {
+	const maxListedTracks = 10
+
+	title := fmt.Sprintf("🗑️ **%d Tracks Removed**", len(tracks))
+	if len(tracks) == 1 {
+		title = "🗑️ **1 Track Removed**"
+	}
+
+	lines := make([]string, 0, maxListedTracks+1)
+	for _, track := range tracks[:min(len(tracks), maxListedTracks)] {
+		lines = append(lines, fmt.Sprintf("`-` %s", track.TrackName))
+	}
+
+	if len(tracks) > maxListedTracks {
+		lines = append(lines, fmt.Sprintf("*...and %d more*", len(tracks)-maxListedTracks))
+	}
+
+	return &discordgo.MessageEmbed{
+		Description: title,
+		Color:       Blurple,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  reason,
+				Value: strings.Join(lines, "\n"),
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text:    "Removed by: " + member.User.Username,
+			IconURL: member.AvatarURL(""),
+		},
+	}
+}
+
 func QueueEmbed(tracks []*audiotype.TrackData, pageNumber int, totalPages int, separator int, guild *discordgo.Guild) *discordgo.MessageEmbed {
 	result := &discordgo.MessageEmbed{
 		Title: guild.Name + "'s Queue",
@@ -178,6 +223,87 @@ func QueueEmbed(tracks []*audiotype.TrackData, pageNumber int, totalPages int, s
 	return result
 }
 
+// PodcastEpisodesEmbed lists a page of a podcast's episodes, numbered the same way as the episode select menu.
+func PodcastEpisodesEmbed(feed *podcast.Feed, episodes []*podcast.Episode, pageNumber int, totalPages int, separator int) *discordgo.MessageEmbed {
+	result := &discordgo.MessageEmbed{
+		Title:       feed.Title,
+		Description: "Pick the episodes you'd like to queue from the menu below",
+		Color:       LightPink,
+		Thumbnail:   Thumbnail(feed.ImageURL),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Page %d / %d • %d episodes", pageNumber, totalPages, len(feed.Episodes)),
+		},
+	}
+
+	if feed.Author != "" {
+		result.Author = &discordgo.MessageEmbedAuthor{Name: feed.Author}
+	}
+
+	episodeIndex := ((pageNumber * separator) + 1) - separator
+	for _, episode := range episodes {
+		details := []string{}
+		if !episode.Published.IsZero() {
+			details = append(details, episode.Published.Format("Jan 2, 2006"))
+		}
+
+		if episode.Duration > 0 {
+			details = append(details, audiotype.FormatDuration(episode.Duration))
+		}
+
+		if len(details) == 0 {
+			details = append(details, "Unknown length")
+		}
+
+		result.Fields = append(result.Fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("%d: %s", episodeIndex, episode.Title),
+			Value: fmt.Sprintf("`%s`", strings.Join(details, " • ")),
+		})
+
+		episodeIndex++
+	}
+
+	return result
+}
+
+// trackLength formats the duration of the track, live streams have none.
+func trackLength(trackData *audiotype.TrackData) string {
+	if trackData.AudioType == audiotype.LiveRadio {
+		return "🔴 Live"
+	}
+
+	return audiotype.FormatDuration(trackData.Duration)
+}
+
+// SearchResultsEmbed lists the results of a search, numbered the same way as the result select menu.
+func SearchResultsEmbed(query string, source string, tracks []*audiotype.TrackData) *discordgo.MessageEmbed {
+	result := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("Results for %q", query),
+		Description: "Pick the track you'd like to queue from the menu below",
+		Color:       LightPink,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("%d results from %s", len(tracks), source),
+		},
+	}
+
+	if len(tracks) > 0 {
+		result.Thumbnail = Thumbnail(tracks[0].TrackImageURL)
+	}
+
+	for i, track := range tracks {
+		duration := "Unknown length"
+		if track.Duration > 0 {
+			duration = audiotype.FormatDuration(track.Duration)
+		}
+
+		result.Fields = append(result.Fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("%d: %s", i+1, track.TrackName),
+			Value: fmt.Sprintf("`%s`", duration),
+		})
+	}
+
+	return result
+}
+
 func MusicPlayerEmbed(trackData *audiotype.TrackData) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
 		Title:       "Now Playing 🎵",
@@ -186,7 +312,7 @@ func MusicPlayerEmbed(trackData *audiotype.TrackData) *discordgo.MessageEmbed {
 		Fields: []*discordgo.MessageEmbedField{
 			{
 				Name:   "`Length:`",
-				Value:  audiotype.FormatDuration(trackData.Duration),
+				Value:  trackLength(trackData),
 				Inline: true,
 			},
 			{
@@ -288,9 +414,7 @@ func AddedTracksToQueueEmbed(trackData *audiotype.Data, member *discordgo.Member
 
 		baseMessageEmbed.Description = fmt.Sprintf("**%s** added to queue", trackData.PlaylistData.PlaylistName)
 
-		baseMessageEmbed.Thumbnail = &discordgo.MessageEmbedThumbnail{
-			URL: trackData.PlaylistData.PlaylistImageURL,
-		}
+		baseMessageEmbed.Thumbnail = Thumbnail(trackData.PlaylistData.PlaylistImageURL)
 
 		baseMessageEmbed.Fields = append(baseMessageEmbed.Fields, &discordgo.MessageEmbedField{
 			Name:   "**Enqueued**",
@@ -300,15 +424,13 @@ func AddedTracksToQueueEmbed(trackData *audiotype.Data, member *discordgo.Member
 	} else {
 		addedTrack := trackData.Tracks[0]
 
-		baseMessageEmbed.Thumbnail = &discordgo.MessageEmbedThumbnail{
-			URL: addedTrack.TrackImageURL,
-		}
+		baseMessageEmbed.Thumbnail = Thumbnail(addedTrack.TrackImageURL)
 
 		baseMessageEmbed.Description = fmt.Sprintf("**%s** added to queue", addedTrack.TrackName)
 
 		baseMessageEmbed.Fields = append(baseMessageEmbed.Fields, &discordgo.MessageEmbedField{
 			Name:   "**Duration**",
-			Value:  fmt.Sprintf("`%s`", audiotype.FormatDuration(addedTrack.Duration)),
+			Value:  fmt.Sprintf("`%s`", trackLength(addedTrack)),
 			Inline: true,
 		})
 	}
@@ -384,13 +506,13 @@ func HelpMenuEmbed(commands []*discordgo.ApplicationCommand) *discordgo.MessageE
 		Color:       LightPink,
 	}
 
+	// discord caps embeds at 25 fields, so commands are listed in the description
+	commandLines := make([]string, 0, len(commands))
 	for _, command := range commands {
-		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("**%s**", command.Name),
-			Value:  fmt.Sprintf("``%s``", command.Description),
-			Inline: true,
-		})
+		commandLines = append(commandLines, fmt.Sprintf("**/%s** — ``%s``", command.Name, command.Description))
 	}
 
+	embed.Description += "\n\n" + strings.Join(commandLines, "\n")
+
 	return embed
 }
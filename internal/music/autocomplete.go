@@ -0,0 +1,266 @@
+package music
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/internal/logger"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/youtube"
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// autocomplete allows at most 25 choices, with values up to 100 characters
+	maxChoices     int = 25
+	maxChoiceValue int = 100
+	// keystrokes closer together than this only look up suggestions for the last one
+	autocompleteDebounce time.Duration = 300 * time.Millisecond
+	// discord discards autocomplete responses that take longer than three seconds
+	autocompleteBudget  time.Duration = 2 * time.Second
+	searchSuggestionTTL time.Duration = 10 * time.Minute
+	likedTracksTTL      time.Duration = time.Minute
+	maxCachedSearches   int           = 512
+	maxCachedLikes      int           = 256
+	maxRecentPlays      int           = 10
+	// recent plays and liked tracks are each capped, leaving room for search suggestions
+	maxPersonalSuggestions int = 5
+)
+
+type recentPlay struct {
+	name  string
+	value string
+}
+
+// playAutocomplete suggests what to play while the /play query is typed, from the user's
+// recent plays and liked tracks followed by YouTube's search suggestions.
+type playAutocomplete struct {
+	httpClient      *http.Client
+	fireStoreClient FireStore
+	searches        *expiringCache[[]string]
+	likes           *expiringCache[[]*audiotype.TrackData]
+
+	mu sync.Mutex
+	// keystrokes holds the number of each user's latest keystroke, earlier ones are dropped
+	keystrokes  map[string]uint64
+	keystroke   uint64
+	recentPlays map[string][]recentPlay
+}
+
+func newPlayAutocomplete(httpClient *http.Client, fs FireStore) *playAutocomplete {
+	return &playAutocomplete{
+		httpClient:      httpClient,
+		fireStoreClient: fs,
+		searches:        newExpiringCache[[]string](searchSuggestionTTL, maxCachedSearches),
+		likes:           newExpiringCache[[]*audiotype.TrackData](likedTracksTTL, maxCachedLikes),
+		keystrokes:      make(map[string]uint64),
+		recentPlays:     make(map[string][]recentPlay),
+	}
+}
+
+func userKey(guildID string, userID string) string {
+	return guildID + "/" + userID
+}
+
+// debounce waits to see whether the user keeps typing, returning false if a newer
+// keystroke arrived in the meantime.
+func (a *playAutocomplete) debounce(ctx context.Context, userID string) bool {
+	a.mu.Lock()
+	a.keystroke++
+	keystroke := a.keystroke
+	a.keystrokes[userID] = keystroke
+	a.mu.Unlock()
+
+	select {
+	case <-time.After(autocompleteDebounce):
+	case <-ctx.Done():
+		return false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.keystrokes[userID] != keystroke {
+		return false
+	}
+
+	delete(a.keystrokes, userID)
+
+	return true
+}
+
+// recordPlay remembers what the user played in the guild, values too long to be a
+// choice are left out. Recent plays are kept in memory and reset with the bot.
+func (a *playAutocomplete) recordPlay(guildID string, userID string, name string, value string) {
+	if value == "" || len(value) > maxChoiceValue {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := userKey(guildID, userID)
+	plays := []recentPlay{{name: name, value: value}}
+
+	for _, play := range a.recentPlays[key] {
+		if play.value != value && len(plays) < maxRecentPlays {
+			plays = append(plays, play)
+		}
+	}
+
+	a.recentPlays[key] = plays
+}
+
+func (a *playAutocomplete) recent(guildID string, userID string) []recentPlay {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]recentPlay(nil), a.recentPlays[userKey(guildID, userID)]...)
+}
+
+func (a *playAutocomplete) likedTracks(ctx context.Context, guildID string, userID string) ([]*audiotype.TrackData, error) {
+	key := userKey(guildID, userID)
+	if tracks, ok := a.likes.get(key); ok {
+		return tracks, nil
+	}
+
+	tracks, err := getUserLikes(ctx, a.fireStoreClient, guildID, userID)
+	if err != nil && !errors.Is(err, errUserHasNoLikes) {
+		return nil, err
+	}
+
+	a.likes.set(key, tracks)
+
+	return tracks, nil
+}
+
+func (a *playAutocomplete) searchSuggestions(ctx context.Context, query string) ([]string, error) {
+	key := strings.ToLower(strings.TrimSpace(query))
+	if suggestions, ok := a.searches.get(key); ok {
+		return suggestions, nil
+	}
+
+	suggestions, err := youtube.Suggest(ctx, a.httpClient, query)
+	if err != nil {
+		return nil, err
+	}
+
+	a.searches.set(key, suggestions)
+
+	return suggestions, nil
+}
+
+// playSuggestions returns the choices for the /play query, it returns false when the
+// user kept typing and the suggestions would be outdated.
+func (m *PlayerCog) playSuggestions(interaction *discordgo.InteractionCreate, query string) ([]*discordgo.ApplicationCommandOptionChoice, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), autocompleteBudget)
+	defer cancel()
+
+	userID := interaction.Member.User.ID
+	if !m.autocomplete.debounce(ctx, userID) {
+		return nil, false
+	}
+
+	var (
+		eg       errgroup.Group
+		likes    []*audiotype.TrackData
+		searches []string
+	)
+
+	eg.Go(func() error {
+		var err error
+		if likes, err = m.autocomplete.likedTracks(ctx, interaction.GuildID, userID); err != nil {
+			m.logger.Warn("unable to retrieve liked tracks for suggestions", zap.Error(err), logger.UserID(userID))
+		}
+
+		return nil
+	})
+
+	// links are played as they are, there's nothing to suggest for them
+	if strings.TrimSpace(query) != "" && !strings.Contains(query, "://") {
+		eg.Go(func() error {
+			var err error
+			if searches, err = m.autocomplete.searchSuggestions(ctx, query); err != nil {
+				m.logger.Warn("unable to retrieve search suggestions", zap.Error(err))
+			}
+
+			return nil
+		})
+	}
+
+	_ = eg.Wait()
+
+	suggestions := []*discordgo.ApplicationCommandOptionChoice{}
+	seen := map[string]struct{}{}
+
+	addChoice := func(name string, value string) {
+		if _, ok := seen[value]; ok || len(suggestions) >= maxChoices {
+			return
+		}
+
+		seen[value] = struct{}{}
+		suggestions = append(suggestions, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateChoiceName(name),
+			Value: value,
+		})
+	}
+
+	lowerQuery := strings.ToLower(strings.TrimSpace(query))
+	matches := func(texts ...string) bool {
+		for _, text := range texts {
+			if strings.Contains(strings.ToLower(text), lowerQuery) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	added := 0
+	for _, play := range m.autocomplete.recent(interaction.GuildID, userID) {
+		if added < maxPersonalSuggestions && matches(play.name, play.value) {
+			addChoice("🕘 "+play.name, play.value)
+			added++
+		}
+	}
+
+	added = 0
+	for _, track := range likes {
+		if added < maxPersonalSuggestions && matches(track.TrackName) {
+			addChoice("❤️ "+track.TrackName, trackChoiceValue(track))
+			added++
+		}
+	}
+
+	for _, search := range searches {
+		addChoice("🔎 "+search, truncateChoiceValue(search))
+	}
+
+	return suggestions, true
+}
+
+// trackChoiceValue returns what to play a track by, its link when it has one that fits
+// and otherwise its name, which is searched for.
+func trackChoiceValue(track *audiotype.TrackData) string {
+	if strings.HasPrefix(track.Query, "http") && len(track.Query) <= maxChoiceValue {
+		return track.Query
+	}
+
+	return truncateChoiceValue(track.TrackName)
+}
+
+func truncateChoiceValue(value string) string {
+	for len(value) > maxChoiceValue {
+		_, size := utf8.DecodeLastRuneInString(value)
+		value = value[:len(value)-size]
+	}
+
+	return value
+}
@@ -6,18 +6,31 @@ import (
 	"fmt"
 	"maps"
 	"net/http"
+	"net/url"
 	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	fs "cloud.google.com/go/firestore"
 	"github.com/TeddyKahwaji/spice-tunes-go/internal/embeds"
 	"github.com/TeddyKahwaji/spice-tunes-go/internal/logger"
 	"github.com/TeddyKahwaji/spice-tunes-go/internal/util"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/applemusic"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiocache"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiofile"
 	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
 	"github.com/TeddyKahwaji/spice-tunes-go/pkg/commands"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/deezer"
 	"github.com/TeddyKahwaji/spice-tunes-go/pkg/funcs"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/library"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/radio"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/soundcloud"
 	"github.com/TeddyKahwaji/spice-tunes-go/pkg/spotify"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/tidal"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/views"
 	"github.com/TeddyKahwaji/spice-tunes-go/pkg/youtube"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/ytdlp"
 	"github.com/bwmarrin/discordgo"
 	"go.uber.org/zap"
 )
@@ -33,9 +46,44 @@ type TrackDataRetriever interface {
 	GetTracksData(ctx context.Context, audioType audiotype.SupportedAudioType, query string) (*audiotype.Data, error)
 }
 
+// TrackSearcher returns several matches for a search query, ranked best first.
+type TrackSearcher interface {
+	SearchTracks(ctx context.Context, query string, limit int) ([]*audiotype.TrackData, error)
+}
+
+// YoutubeRetriever is satisfied by both the Data API and the yt-dlp backed youtube wrappers.
+type YoutubeRetriever interface {
+	TrackDataRetriever
+	TrackSearcher
+	// RetrievalTimeout reports how long retrieving tracks may take, as it depends on whether yt-dlp serves them
+	RetrievalTimeout(audioType audiotype.SupportedAudioType) time.Duration
+}
+
 var (
 	_ TrackDataRetriever = (*spotify.SpotifyClientWrapper)(nil)
 	_ TrackDataRetriever = (*youtube.SearchWrapper)(nil)
+	_ TrackDataRetriever = (*youtube.DLPWrapper)(nil)
+	_ TrackDataRetriever = (*soundcloud.SoundCloudWrapper)(nil)
+	_ TrackDataRetriever = (*applemusic.AppleMusicWrapper)(nil)
+	_ TrackDataRetriever = (*deezer.DeezerWrapper)(nil)
+	_ TrackDataRetriever = (*tidal.TidalWrapper)(nil)
+	_ TrackDataRetriever = (*ytdlp.GenericWrapper)(nil)
+	_ TrackDataRetriever = (*audiofile.DirectFileWrapper)(nil)
+	_ TrackDataRetriever = (*library.Wrapper)(nil)
+
+	_ TrackSearcher    = (*spotify.SpotifyClientWrapper)(nil)
+	_ YoutubeRetriever = (*youtube.SearchWrapper)(nil)
+	_ YoutubeRetriever = (*youtube.DLPWrapper)(nil)
+)
+
+// DownloadMode determines how tracks are handed from yt-dlp to the encoder.
+type DownloadMode string
+
+const (
+	// DownloadModeStream pipes the download straight into the encoder.
+	DownloadModeStream DownloadMode = "stream"
+	// DownloadModeTempFile downloads the whole track to a temporary file before encoding it.
+	DownloadModeTempFile DownloadMode = "tempfile"
 )
 
 type PlayerCog struct {
@@ -47,7 +95,19 @@ type PlayerCog struct {
 	songSignal            chan *guildPlayer
 	guildVoiceStates      map[string]*guildPlayer
 	spotifyClient         *spotify.SpotifyClientWrapper
-	ytSearchWrapper       *youtube.SearchWrapper
+	ytSearchWrapper       YoutubeRetriever
+	soundCloudWrapper     *soundcloud.SoundCloudWrapper
+	appleMusicWrapper     *applemusic.AppleMusicWrapper
+	deezerWrapper         *deezer.DeezerWrapper
+	tidalWrapper          *tidal.TidalWrapper
+	genericWrapper        *ytdlp.GenericWrapper
+	directFileWrapper     *audiofile.DirectFileWrapper
+	library               *library.Library
+	libraryWrapper        *library.Wrapper
+	downloadMode          DownloadMode
+	audioCache            *audiocache.Cache
+	trackResolver         *trackResolver
+	autocomplete          *playAutocomplete
 }
 
 type CogConfig struct {
@@ -56,7 +116,18 @@ type CogConfig struct {
 	Logger               *zap.Logger
 	HTTPClient           *http.Client
 	SpotifyWrapper       *spotify.SpotifyClientWrapper
-	YoutubeSearchWrapper *youtube.SearchWrapper
+	YoutubeSearchWrapper YoutubeRetriever
+	SoundCloudWrapper    *soundcloud.SoundCloudWrapper
+	AppleMusicWrapper    *applemusic.AppleMusicWrapper
+	DeezerWrapper        *deezer.DeezerWrapper
+	TidalWrapper         *tidal.TidalWrapper
+	GenericWrapper       *ytdlp.GenericWrapper
+	// DownloadMode defaults to DownloadModeStream when left empty.
+	DownloadMode DownloadMode
+	// AudioCache is optional, when set played tracks are kept on disk and reused.
+	AudioCache *audiocache.Cache
+	// Library is optional, when set its tracks can be played with the "library:" prefix.
+	Library *library.Library
 }
 
 func NewPlayerCog(config *CogConfig) (*PlayerCog, error) {
@@ -64,11 +135,25 @@ func NewPlayerCog(config *CogConfig) (*PlayerCog, error) {
 		config.HTTPClient == nil ||
 		config.SpotifyWrapper == nil ||
 		config.YoutubeSearchWrapper == nil ||
+		config.SoundCloudWrapper == nil ||
+		config.AppleMusicWrapper == nil ||
+		config.DeezerWrapper == nil ||
+		config.TidalWrapper == nil ||
+		config.GenericWrapper == nil ||
 		config.Session == nil ||
 		config.FireStoreClient == nil {
 		return nil, errors.New("config was populated with nil value")
 	}
 
+	downloadMode := config.DownloadMode
+	switch downloadMode {
+	case "":
+		downloadMode = DownloadModeStream
+	case DownloadModeStream, DownloadModeTempFile:
+	default:
+		return nil, fmt.Errorf("unsupported download mode %q", downloadMode)
+	}
+
 	musicCog := &PlayerCog{
 		fireStoreClient:       config.FireStoreClient,
 		session:               config.Session,
@@ -79,6 +164,22 @@ func NewPlayerCog(config *CogConfig) (*PlayerCog, error) {
 		guildVoiceStates:      make(map[string]*guildPlayer),
 		spotifyClient:         config.SpotifyWrapper,
 		ytSearchWrapper:       config.YoutubeSearchWrapper,
+		soundCloudWrapper:     config.SoundCloudWrapper,
+		appleMusicWrapper:     config.AppleMusicWrapper,
+		deezerWrapper:         config.DeezerWrapper,
+		tidalWrapper:          config.TidalWrapper,
+		genericWrapper:        config.GenericWrapper,
+		directFileWrapper:     audiofile.NewDirectFileWrapper(),
+		downloadMode:          downloadMode,
+		audioCache:            config.AudioCache,
+		autocomplete:          newPlayAutocomplete(config.HTTPClient, config.FireStoreClient),
+	}
+
+	musicCog.trackResolver = newTrackResolver(config.FireStoreClient, musicCog.searchVideo)
+
+	if config.Library != nil {
+		musicCog.library = config.Library
+		musicCog.libraryWrapper = library.NewWrapper(config.Library)
 	}
 
 	return musicCog, nil
@@ -153,6 +254,28 @@ func (m *PlayerCog) playLikes(session *discordgo.Session, interaction *discordgo
 }
 
 func (m *PlayerCog) play(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
+	return m.playAt(session, interaction, endOfQueue)
+}
+
+func (m *PlayerCog) playNext(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
+	return m.playAt(session, interaction, 1)
+}
+
+func (m *PlayerCog) insert(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
+	position := endOfQueue
+
+	for _, option := range interaction.ApplicationCommandData().Options {
+		if option.Name == "position" {
+			position = int(option.IntValue())
+		}
+	}
+
+	return m.playAt(session, interaction, position)
+}
+
+// playAt queues what the play command was given at a position counted from the current
+// track, multi-track sources being inserted as one block.
+func (m *PlayerCog) playAt(session *discordgo.Session, interaction *discordgo.InteractionCreate, position int) error {
 	isInVoiceChannel, err := m.verifyInChannelAndSendError(session, interaction)
 	if err != nil {
 		return fmt.Errorf("verifying in voice channel: %w", err)
@@ -172,13 +295,10 @@ func (m *PlayerCog) play(session *discordgo.Session, interaction *discordgo.Inte
 		return fmt.Errorf("deferring message: %w", err)
 	}
 
-	options := interaction.ApplicationCommandData().Options
-	query := options[0].StringValue()
-
-	audioType, err := audiotype.DetermineAudioType(query)
+	query, audioType, err := playQuery(interaction.ApplicationCommandData())
 	if err != nil {
 		if errors.Is(err, audiotype.ErrUnsupportedAudioType) {
-			invalidUsageEmbed := embeds.ErrorMessageEmbed("The provided track type is not supported. Please enter a YouTube or Spotify link.")
+			invalidUsageEmbed := embeds.ErrorMessageEmbed("The provided track type is not supported. Please enter a link or search query, or attach an audio file.")
 			msgData := util.MessageData{
 				Embeds: invalidUsageEmbed,
 				Type:   discordgo.InteractionResponseChannelMessageWithSource,
@@ -195,14 +315,54 @@ func (m *PlayerCog) play(session *discordgo.Session, interaction *discordgo.Inte
 		return fmt.Errorf("determining audio type: %w", err)
 	}
 
+	// a video opened from a playlist could mean either, so the requester is asked
+	if audioType == audiotype.YoutubeSong && audiotype.IsYoutubeVideoInList(query) {
+		return m.promptVideoOrList(session, interaction, query, position)
+	}
+
+	return m.queueQuery(session, interaction, audioType, query, position)
+}
+
+// queueQuery retrieves the tracks of a play query and inserts them into the queue at the
+// position, the interaction must already have been deferred.
+func (m *PlayerCog) queueQuery(session *discordgo.Session, interaction *discordgo.InteractionCreate, audioType audiotype.SupportedAudioType, query string, position int) error {
 	guildPlayer := m.guildVoiceStates[interaction.GuildID]
 
-	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancelFunc := context.WithTimeout(context.Background(), m.retrievalTimeout(audioType))
 	defer cancelFunc()
 	ctx = context.WithValue(ctx, audiotype.ContextKey("requesterName"), interaction.Member.User.Username)
 
 	trackData, err := m.retrieveTracks(ctx, audioType, query)
 	if err != nil {
+		if errors.Is(err, errNoLibrary) {
+			msgData := util.MessageData{
+				Embeds: embeds.ErrorMessageEmbed("This bot does not have a music library set up"),
+				Type:   discordgo.InteractionResponseChannelMessageWithSource,
+			}
+
+			err := util.SendMessage(session, interaction.Interaction, true, msgData, util.WithDeletion(10*time.Second, interaction.ChannelID))
+			if err != nil {
+				return fmt.Errorf("sending follow up message: %w", err)
+			}
+
+			return nil
+		}
+
+		if errors.Is(err, audiotype.ErrUnsupportedAudioType) || errors.Is(err, ytdlp.ErrExtractorNotAllowed) {
+			invalidUsageEmbed := embeds.ErrorMessageEmbed("The provided link is not supported. Please enter a link from YouTube, Spotify, SoundCloud, Apple Music, Deezer, Tidal or another supported site.")
+			msgData := util.MessageData{
+				Embeds: invalidUsageEmbed,
+				Type:   discordgo.InteractionResponseChannelMessageWithSource,
+			}
+
+			err := util.SendMessage(session, interaction.Interaction, true, msgData, util.WithDeletion(10*time.Second, interaction.ChannelID))
+			if err != nil {
+				return fmt.Errorf("sending follow up message: %w", err)
+			}
+
+			return nil
+		}
+
 		if errors.Is(err, audiotype.ErrSearchQueryNotFound) {
 			msgData := util.MessageData{
 				Embeds: embeds.NotFoundEmbed(),
@@ -224,10 +384,138 @@ func (m *PlayerCog) play(session *discordgo.Session, interaction *discordgo.Inte
 		return errors.New("unable to retrieve audio data")
 	}
 
-	if err := m.addToQueue(session, interaction, trackData, guildPlayer); err != nil {
+	if err := m.insertIntoQueue(session, interaction, trackData, guildPlayer, position); err != nil {
 		return fmt.Errorf("adding to queue: %w", err)
 	}
 
+	// attachment links expire, so they aren't worth suggesting again
+	if audioType != audiotype.DirectAudioFile {
+		m.autocomplete.recordPlay(interaction.GuildID, interaction.Member.User.ID, playedName(trackData), query)
+	}
+
+	return nil
+}
+
+// playedName describes what was queued, the playlist for multi-track sources.
+func playedName(trackData *audiotype.Data) string {
+	if trackData.PlaylistData != nil && trackData.PlaylistData.PlaylistName != "" {
+		return trackData.PlaylistData.PlaylistName
+	}
+
+	return trackData.Tracks[0].TrackName
+}
+
+// promptVideoOrList asks whoever used /play whether to queue the video a link points
+// at or the whole list it was opened from.
+func (m *PlayerCog) promptVideoOrList(session *discordgo.Session, interaction *discordgo.InteractionCreate, query string, position int) error {
+	const (
+		videoButtonID = "QueueVideoBtn"
+		listButtonID  = "QueueListBtn"
+	)
+
+	listLabel := "Whole playlist"
+	if audiotype.YoutubeListType(query) == audiotype.YoutubeMix {
+		listLabel = "Whole mix"
+	}
+
+	viewConfig := &views.Config{
+		Embeds: []*discordgo.MessageEmbed{
+			embeds.MusicPlayerActionEmbed("This link points at a video inside a playlist, what should be queued?", *interaction.Member),
+		},
+		Components: &views.ComponentHandler{
+			MessageComponents: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.Button{
+							CustomID: videoButtonID,
+							Label:    "This video",
+							Style:    discordgo.PrimaryButton,
+							Emoji: &discordgo.ComponentEmoji{
+								Name: "🎵",
+							},
+						},
+						discordgo.Button{
+							CustomID: listButtonID,
+							Label:    listLabel,
+							Style:    discordgo.SecondaryButton,
+							Emoji: &discordgo.ComponentEmoji{
+								Name: "📃",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	promptView := views.NewView(viewConfig, views.WithLogger(m.logger), views.WithDeletion(time.Minute))
+
+	var chosen sync.Once
+
+	handler := func(passedInteraction *discordgo.Interaction) error {
+		if passedInteraction.Member.User.ID != interaction.Member.User.ID {
+			if err := session.InteractionRespond(passedInteraction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: "Only whoever played this link can choose",
+					Flags:   discordgo.MessageFlagsEphemeral,
+				},
+			}); err != nil {
+				return fmt.Errorf("sending ephemeral message: %w", err)
+			}
+
+			return nil
+		}
+
+		audioType := audiotype.YoutubeSong
+		if passedInteraction.MessageComponentData().CustomID == listButtonID {
+			audioType = audiotype.YoutubeListType(query)
+		}
+
+		var (
+			err     error
+			handled bool
+		)
+
+		chosen.Do(func() {
+			handled = true
+
+			if err = session.InteractionRespond(passedInteraction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseDeferredMessageUpdate,
+			}); err != nil {
+				err = fmt.Errorf("deferring message update: %w", err)
+				return
+			}
+
+			if err = promptView.DeleteView(session); err != nil {
+				m.logger.Warn("unable to delete video or playlist prompt", zap.Error(err), logger.GuildID(interaction.GuildID))
+			}
+
+			// the player may have left while the requester was choosing
+			if err = m.joinAndCreateGuildPlayer(session, interaction); err != nil {
+				err = fmt.Errorf("joining and creating guild player: %w", err)
+				return
+			}
+
+			err = m.queueQuery(session, interaction, audioType, query, position)
+		})
+
+		// a click that lands after the choice was made still has to be acknowledged
+		if !handled {
+			if err := session.InteractionRespond(passedInteraction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseDeferredMessageUpdate,
+			}); err != nil {
+				return fmt.Errorf("deferring message update: %w", err)
+			}
+		}
+
+		return err
+	}
+
+	if err := promptView.SendView(interaction.Interaction, session, handler); err != nil {
+		return fmt.Errorf("sending video or playlist prompt: %w", err)
+	}
+
 	return nil
 }
 
@@ -302,7 +590,7 @@ func (m *PlayerCog) skip(session *discordgo.Session, interaction *discordgo.Inte
 		return nil
 	}
 
-	if guildPlayer.hasNext() {
+	if guildPlayer.canSkip() {
 		guildPlayer.skip()
 		if err := guildPlayer.refreshState(session); err != nil {
 			m.logger.Warn("unable to refresh view state", zap.Error(err), logger.GuildID(interaction.GuildID))
@@ -324,6 +612,73 @@ func (m *PlayerCog) skip(session *discordgo.Session, interaction *discordgo.Inte
 	return nil
 }
 
+func (m *PlayerCog) skipTo(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
+	isInVoiceChannel, err := m.verifyInChannelAndSendError(session, interaction)
+	if err != nil {
+		return fmt.Errorf("verifying in voice channel: %w", err)
+	}
+
+	if !isInVoiceChannel {
+		return nil
+	}
+
+	guildPlayer, ok := m.guildVoiceStates[interaction.GuildID]
+	if !ok || guildPlayer.isQueueDepleted() || guildPlayer.isNotActive() {
+		invalidUsageEmbed := embeds.ErrorMessageEmbed("Nothing is playing in this server")
+		msgData := util.MessageData{
+			Embeds: invalidUsageEmbed,
+			Type:   discordgo.InteractionResponseChannelMessageWithSource,
+			FlagWrapper: &util.FlagWrapper{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		}
+
+		err := util.SendMessage(session, interaction.Interaction, false, msgData)
+		if err != nil {
+			return fmt.Errorf("interaction response: %w", err)
+		}
+
+		return nil
+	}
+
+	position := int(interaction.ApplicationCommandData().Options[0].IntValue())
+
+	if err := guildPlayer.skipTo(guildPlayer.getCurrentPointer() + position); err != nil {
+		invalidUsageEmbed := embeds.ErrorMessageEmbed("The position you entered is incorrect, please check the queue and try again")
+		msgData := util.MessageData{
+			Embeds: invalidUsageEmbed,
+			Type:   discordgo.InteractionResponseChannelMessageWithSource,
+			FlagWrapper: &util.FlagWrapper{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		}
+
+		err := util.SendMessage(session, interaction.Interaction, false, msgData)
+		if err != nil {
+			return fmt.Errorf("interaction response: %w", err)
+		}
+
+		return nil
+	}
+
+	track := guildPlayer.getCurrentSong()
+
+	if err := guildPlayer.refreshState(session); err != nil {
+		m.logger.Warn("unable to refresh view state", zap.Error(err), logger.GuildID(interaction.GuildID))
+	}
+
+	guildPlayer.sendStopSignal()
+
+	if err = util.SendMessage(session, interaction.Interaction, false, util.MessageData{
+		Embeds: embeds.MusicPlayerActionEmbed(fmt.Sprintf("⏩ ***Skipped to %s*** 👍", track.TrackName), *interaction.Member),
+		Type:   discordgo.InteractionResponseChannelMessageWithSource,
+	}, util.WithDeletion(30*time.Second, interaction.ChannelID)); err != nil {
+		return fmt.Errorf("sending message: %w", err)
+	}
+
+	return nil
+}
+
 func (m *PlayerCog) pause(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
 	isInVoiceChannel, err := m.verifyInChannelAndSendError(session, interaction)
 	if err != nil {
@@ -455,6 +810,342 @@ func (m *PlayerCog) rewind(session *discordgo.Session, interaction *discordgo.In
 	return nil
 }
 
+func (m *PlayerCog) seek(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
+	isInVoiceChannel, err := m.verifyInChannelAndSendError(session, interaction)
+	if err != nil {
+		return fmt.Errorf("verifying in channel: %w", err)
+	}
+
+	if !isInVoiceChannel {
+		return nil
+	}
+
+	guildPlayer, ok := m.guildVoiceStates[interaction.GuildID]
+	if !ok || guildPlayer.isQueueDepleted() || guildPlayer.isNotActive() {
+		invalidUsageEmbed := embeds.ErrorMessageEmbed("Nothing is playing in this server")
+		msgData := util.MessageData{
+			Type:   discordgo.InteractionResponseChannelMessageWithSource,
+			Embeds: invalidUsageEmbed,
+			FlagWrapper: &util.FlagWrapper{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		}
+
+		err := util.SendMessage(session, interaction.Interaction, false, msgData)
+		if err != nil {
+			return fmt.Errorf("interaction response: %w", err)
+		}
+
+		return nil
+	}
+
+	options := interaction.ApplicationCommandData().Options
+	timestamp := options[0].StringValue()
+
+	position, err := audiotype.ParseTimestamp(timestamp)
+	if err == nil {
+		err = guildPlayer.seek(position)
+	}
+
+	if err != nil {
+		if errors.Is(err, audiotype.ErrInvalidTimestamp) || errors.Is(err, errInvalidSeek) {
+			invalidUsageEmbed := embeds.ErrorMessageEmbed(fmt.Sprintf("`%s` is not a valid position in the current track, try something like `1:30` or `1:02:03`", timestamp))
+			msgData := util.MessageData{
+				Type:   discordgo.InteractionResponseChannelMessageWithSource,
+				Embeds: invalidUsageEmbed,
+				FlagWrapper: &util.FlagWrapper{
+					Flags: discordgo.MessageFlagsEphemeral,
+				},
+			}
+
+			err := util.SendMessage(session, interaction.Interaction, false, msgData)
+			if err != nil {
+				return fmt.Errorf("interaction response: %w", err)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("seeking: %w", err)
+	}
+
+	if err := guildPlayer.refreshState(session); err != nil {
+		m.logger.Warn("unable to refresh view state", zap.Error(err), logger.GuildID(interaction.GuildID))
+	}
+
+	if err = util.SendMessage(session, interaction.Interaction, false, util.MessageData{
+		Type:   discordgo.InteractionResponseChannelMessageWithSource,
+		Embeds: embeds.MusicPlayerActionEmbed(fmt.Sprintf("⏩ ***Jumped to %s*** 👍", audiotype.FormatDuration(position)), *interaction.Member),
+	}, util.WithDeletion(30*time.Second, interaction.ChannelID)); err != nil {
+		return fmt.Errorf("sending message: %w", err)
+	}
+
+	return nil
+}
+
+func (m *PlayerCog) loop(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
+	isInVoiceChannel, err := m.verifyInChannelAndSendError(session, interaction)
+	if err != nil {
+		return fmt.Errorf("verifying in channel: %w", err)
+	}
+
+	if !isInVoiceChannel {
+		return nil
+	}
+
+	guildPlayer, ok := m.guildVoiceStates[interaction.GuildID]
+	if !ok || guildPlayer.isQueueDepleted() {
+		invalidUsageEmbed := embeds.ErrorMessageEmbed("Nothing is playing in this server")
+		msgData := util.MessageData{
+			Type:   discordgo.InteractionResponseChannelMessageWithSource,
+			Embeds: invalidUsageEmbed,
+			FlagWrapper: &util.FlagWrapper{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		}
+
+		err := util.SendMessage(session, interaction.Interaction, false, msgData)
+		if err != nil {
+			return fmt.Errorf("interaction response: %w", err)
+		}
+
+		return nil
+	}
+
+	var mode loopMode
+
+	if options := interaction.ApplicationCommandData().Options; len(options) > 0 {
+		mode = loopMode(options[0].StringValue())
+		guildPlayer.setLoopMode(mode)
+	} else {
+		mode = guildPlayer.cycleLoopMode()
+	}
+
+	if err := guildPlayer.refreshState(session); err != nil {
+		m.logger.Warn("unable to refresh view state", zap.Error(err), logger.GuildID(interaction.GuildID))
+	}
+
+	if err = util.SendMessage(session, interaction.Interaction, false, util.MessageData{
+		Type:   discordgo.InteractionResponseChannelMessageWithSource,
+		Embeds: embeds.MusicPlayerActionEmbed(fmt.Sprintf("🔁 ***Loop mode set to %s*** 👍", mode), *interaction.Member),
+	}, util.WithDeletion(30*time.Second, interaction.ChannelID)); err != nil {
+		return fmt.Errorf("sending message: %w", err)
+	}
+
+	return nil
+}
+
+func (m *PlayerCog) volume(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
+	isInVoiceChannel, err := m.verifyInChannelAndSendError(session, interaction)
+	if err != nil {
+		return fmt.Errorf("verifying in channel: %w", err)
+	}
+
+	if !isInVoiceChannel {
+		return nil
+	}
+
+	options := interaction.ApplicationCommandData().Options
+	volume := int(options[0].IntValue())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	// without a player the volume is only saved, it applies once the bot plays again
+	if guildPlayer, ok := m.guildVoiceStates[interaction.GuildID]; ok {
+		err = guildPlayer.setVolume(ctx, volume)
+	} else {
+		err = saveVolume(ctx, m.fireStoreClient, interaction.GuildID, volume)
+	}
+
+	if err != nil {
+		if errors.Is(err, errInvalidVolume) {
+			invalidUsageEmbed := embeds.ErrorMessageEmbed(fmt.Sprintf("The volume must be between `0` and `%d`", maxVolume))
+			msgData := util.MessageData{
+				Type:   discordgo.InteractionResponseChannelMessageWithSource,
+				Embeds: invalidUsageEmbed,
+				FlagWrapper: &util.FlagWrapper{
+					Flags: discordgo.MessageFlagsEphemeral,
+				},
+			}
+
+			err := util.SendMessage(session, interaction.Interaction, false, msgData)
+			if err != nil {
+				return fmt.Errorf("interaction response: %w", err)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("setting volume: %w", err)
+	}
+
+	if err = util.SendMessage(session, interaction.Interaction, false, util.MessageData{
+		Type:   discordgo.InteractionResponseChannelMessageWithSource,
+		Embeds: embeds.MusicPlayerActionEmbed(fmt.Sprintf("🔊 ***Volume set to %d%%*** 👍", volume), *interaction.Member),
+	}, util.WithDeletion(30*time.Second, interaction.ChannelID)); err != nil {
+		return fmt.Errorf("sending message: %w", err)
+	}
+
+	return nil
+}
+
+func (m *PlayerCog) filter(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
+	isInVoiceChannel, err := m.verifyInChannelAndSendError(session, interaction)
+	if err != nil {
+		return fmt.Errorf("verifying in channel: %w", err)
+	}
+
+	if !isInVoiceChannel {
+		return nil
+	}
+
+	guildPlayer, ok := m.guildVoiceStates[interaction.GuildID]
+	if !ok {
+		invalidUsageEmbed := embeds.ErrorMessageEmbed("Nothing is playing in this server")
+		msgData := util.MessageData{
+			Type:   discordgo.InteractionResponseChannelMessageWithSource,
+			Embeds: invalidUsageEmbed,
+			FlagWrapper: &util.FlagWrapper{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		}
+
+		err := util.SendMessage(session, interaction.Interaction, false, msgData)
+		if err != nil {
+			return fmt.Errorf("interaction response: %w", err)
+		}
+
+		return nil
+	}
+
+	options := interaction.ApplicationCommandData().Options
+	filter := audioFilter(options[0].StringValue())
+
+	if err := guildPlayer.setFilter(filter); err != nil {
+		return fmt.Errorf("setting filter: %w", err)
+	}
+
+	if guildPlayer.hasView() {
+		if err := guildPlayer.refreshState(session); err != nil {
+			m.logger.Warn("unable to refresh view state", zap.Error(err), logger.GuildID(interaction.GuildID))
+		}
+	}
+
+	actionMessage := fmt.Sprintf("🎛️ ***%s filter applied*** 👍", filter)
+	if filter == noFilter {
+		actionMessage = "🎛️ ***Filters cleared*** 👍"
+	}
+
+	if err = util.SendMessage(session, interaction.Interaction, false, util.MessageData{
+		Type:   discordgo.InteractionResponseChannelMessageWithSource,
+		Embeds: embeds.MusicPlayerActionEmbed(actionMessage, *interaction.Member),
+	}, util.WithDeletion(30*time.Second, interaction.ChannelID)); err != nil {
+		return fmt.Errorf("sending message: %w", err)
+	}
+
+	return nil
+}
+
+func (m *PlayerCog) fixTrack(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
+	isInVoiceChannel, err := m.verifyInChannelAndSendError(session, interaction)
+	if err != nil {
+		return fmt.Errorf("verifying in channel: %w", err)
+	}
+
+	if !isInVoiceChannel {
+		return nil
+	}
+
+	guildPlayer, ok := m.guildVoiceStates[interaction.GuildID]
+	if !ok || guildPlayer.isQueueDepleted() {
+		invalidUsageEmbed := embeds.ErrorMessageEmbed("The queue is empty")
+		msgData := util.MessageData{
+			Type:   discordgo.InteractionResponseChannelMessageWithSource,
+			Embeds: invalidUsageEmbed,
+			FlagWrapper: &util.FlagWrapper{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		}
+
+		err := util.SendMessage(session, interaction.Interaction, false, msgData)
+		if err != nil {
+			return fmt.Errorf("interaction response: %w", err)
+		}
+
+		return nil
+	}
+
+	var (
+		videoURL string
+		position = guildPlayer.getCurrentPointer()
+	)
+
+	for _, option := range interaction.ApplicationCommandData().Options {
+		switch option.Name {
+		case "url":
+			videoURL = option.StringValue()
+		case "track_position":
+			position += int(option.IntValue())
+		}
+	}
+
+	errorMessage := ""
+
+	matches := audiotype.YoutubeVideoRegex.FindStringSubmatch(videoURL)
+	if len(matches) < 2 {
+		errorMessage = "The link you entered is not a YouTube video"
+	} else if !guildPlayer.isValidPosition(position) {
+		errorMessage = "The position you entered is incorrect, please check the queue and try again"
+	}
+
+	var track *audiotype.TrackData
+	if errorMessage == "" {
+		track = guildPlayer.getTrackAtPosition(position)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+
+		err = m.trackResolver.override(ctx, interaction.GuildID, interaction.Member.User.ID, track, youtube.YoutubeVideoBase+matches[1])
+		if errors.Is(err, errNotSearchTrack) {
+			errorMessage = "Only tracks found through a search, like the ones from Spotify, can be fixed"
+		} else if err != nil {
+			return fmt.Errorf("overriding resolved track: %w", err)
+		}
+	}
+
+	if errorMessage != "" {
+		invalidUsageEmbed := embeds.ErrorMessageEmbed(errorMessage)
+		msgData := util.MessageData{
+			Type:   discordgo.InteractionResponseChannelMessageWithSource,
+			Embeds: invalidUsageEmbed,
+			FlagWrapper: &util.FlagWrapper{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		}
+
+		err := util.SendMessage(session, interaction.Interaction, false, msgData)
+		if err != nil {
+			return fmt.Errorf("interaction response: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := guildPlayer.reloadTrack(track); err != nil {
+		m.logger.Warn("unable to reload fixed track", zap.Error(err), logger.GuildID(interaction.GuildID))
+	}
+
+	if err = util.SendMessage(session, interaction.Interaction, false, util.MessageData{
+		Type:   discordgo.InteractionResponseChannelMessageWithSource,
+		Embeds: embeds.MusicPlayerActionEmbed(fmt.Sprintf("🔧 ***%s will now play the video you linked*** 👍", track.TrackName), *interaction.Member),
+	}, util.WithDeletion(30*time.Second, interaction.ChannelID)); err != nil {
+		return fmt.Errorf("sending message: %w", err)
+	}
+
+	return nil
+}
+
 func (m *PlayerCog) remove(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
 	isInVoiceChannel, err := m.verifyInChannelAndSendError(session, interaction)
 	if err != nil {
@@ -485,6 +1176,28 @@ func (m *PlayerCog) remove(session *discordgo.Session, interaction *discordgo.In
 	}
 
 	options := interaction.ApplicationCommandData().Options
+	if len(options) != 1 {
+		invalidUsageEmbed := embeds.ErrorMessageEmbed("Please choose one way of removing tracks: a position, a range, a member, a text to match or duplicates")
+		msgData := util.MessageData{
+			Embeds: invalidUsageEmbed,
+			Type:   discordgo.InteractionResponseChannelMessageWithSource,
+			FlagWrapper: &util.FlagWrapper{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		}
+
+		err := util.SendMessage(session, interaction.Interaction, false, msgData)
+		if err != nil {
+			return fmt.Errorf("interaction response: %w", err)
+		}
+
+		return nil
+	}
+
+	if options[0].Name != "track_position" {
+		return m.removeMany(session, interaction, guildPlayer, options[0])
+	}
+
 	position := int(options[0].IntValue()) + guildPlayer.getCurrentPointer()
 
 	trackAtPosition, err := guildPlayer.removeTrack(position)
@@ -499,23 +1212,126 @@ func (m *PlayerCog) remove(session *discordgo.Session, interaction *discordgo.In
 				},
 			}
 
-			err := util.SendMessage(session, interaction.Interaction, false, msgData)
-			if err != nil {
-				return fmt.Errorf("interaction response: %w", err)
+			err := util.SendMessage(session, interaction.Interaction, false, msgData)
+			if err != nil {
+				return fmt.Errorf("interaction response: %w", err)
+			}
+
+			return nil
+		}
+		return fmt.Errorf("removing track from guild player: %w", err)
+	}
+
+	if err := guildPlayer.refreshState(session); err != nil {
+		m.logger.Warn("unable to refresh view state", zap.Error(err), logger.GuildID(interaction.GuildID))
+	}
+
+	if err = util.SendMessage(session, interaction.Interaction, false, util.MessageData{
+		Type:   discordgo.InteractionResponseChannelMessageWithSource,
+		Embeds: embeds.MusicPlayerActionEmbed(fmt.Sprintf("**%s** has been removed from the queue", trackAtPosition.TrackName), *interaction.Member),
+	}, util.WithDeletion(30*time.Second, interaction.ChannelID)); err != nil {
+		return fmt.Errorf("sending message: %w", err)
+	}
+
+	return nil
+}
+
+// removeMany removes every upcoming track matching the remove option given, a range of
+// positions, a requester, a text in the track names or duplicates.
+func (m *PlayerCog) removeMany(session *discordgo.Session, interaction *discordgo.InteractionCreate, guildPlayer *guildPlayer, option *discordgo.ApplicationCommandInteractionDataOption) error {
+	sendInvalidUsage := func(message string) error {
+		msgData := util.MessageData{
+			Embeds: embeds.ErrorMessageEmbed(message),
+			Type:   discordgo.InteractionResponseChannelMessageWithSource,
+			FlagWrapper: &util.FlagWrapper{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		}
+
+		if err := util.SendMessage(session, interaction.Interaction, false, msgData); err != nil {
+			return fmt.Errorf("interaction response: %w", err)
+		}
+
+		return nil
+	}
+
+	var (
+		matches func(position int, track *audiotype.TrackData) bool
+		reason  string
+	)
+
+	switch option.Name {
+	case "range":
+		first, last, err := parsePositionRange(option.StringValue())
+		if err != nil || last > guildPlayer.remainingQueueLength() {
+			return sendInvalidUsage("The range you entered is incorrect, please check the queue and try again")
+		}
+
+		matches = func(position int, _ *audiotype.TrackData) bool {
+			return position >= first && position <= last
+		}
+		reason = fmt.Sprintf("Positions %d to %d", first, last)
+
+	case "member":
+		selectedUser := option.UserValue(session)
+		if selectedUser == nil {
+			return errors.New("could not retrieve user value from interaction option")
+		}
+
+		matches = func(_ int, track *audiotype.TrackData) bool {
+			return track.RequesterID == selectedUser.ID
+		}
+		reason = "Requested by " + selectedUser.Username
+
+	case "match":
+		text := strings.ToLower(strings.TrimSpace(option.StringValue()))
+		if text == "" {
+			return sendInvalidUsage("Please enter some text to match track names against")
+		}
+
+		matches = func(_ int, track *audiotype.TrackData) bool {
+			return strings.Contains(strings.ToLower(track.TrackName), text)
+		}
+		reason = fmt.Sprintf("Matching \"%s\"", option.StringValue())
+
+	case "duplicates":
+		if !option.BoolValue() {
+			return sendInvalidUsage("Please choose one way of removing tracks: a position, a range, a member, a text to match or duplicates")
+		}
+
+		// the first time a track is queued is kept, including the track currently playing
+		seen := map[string]struct{}{
+			duplicateKey(guildPlayer.getCurrentSong()): {},
+		}
+
+		matches = func(_ int, track *audiotype.TrackData) bool {
+			key := duplicateKey(track)
+			if _, ok := seen[key]; ok {
+				return true
 			}
 
-			return nil
+			seen[key] = struct{}{}
+
+			return false
 		}
-		return fmt.Errorf("removing track from guild player: %w", err)
+		reason = "Duplicates"
+
+	default:
+		return fmt.Errorf("unknown remove option %q", option.Name)
+	}
+
+	removed := guildPlayer.removeUpcomingTracks(matches)
+	if len(removed) == 0 {
+		return sendInvalidUsage("No tracks in the queue matched, nothing was removed")
 	}
 
 	if err := guildPlayer.refreshState(session); err != nil {
 		m.logger.Warn("unable to refresh view state", zap.Error(err), logger.GuildID(interaction.GuildID))
 	}
 
-	if err = util.SendMessage(session, interaction.Interaction, false, util.MessageData{
+	if err := util.SendMessage(session, interaction.Interaction, false, util.MessageData{
 		Type:   discordgo.InteractionResponseChannelMessageWithSource,
-		Embeds: embeds.MusicPlayerActionEmbed(fmt.Sprintf("**%s** has been removed from the queue", trackAtPosition.TrackName), *interaction.Member),
+		Embeds: embeds.RemovedTracksEmbed(interaction.Member, removed, reason),
 	}, util.WithDeletion(30*time.Second, interaction.ChannelID)); err != nil {
 		return fmt.Errorf("sending message: %w", err)
 	}
@@ -523,6 +1339,15 @@ func (m *PlayerCog) remove(session *discordgo.Session, interaction *discordgo.In
 	return nil
 }
 
+// duplicateKey identifies what a track plays, tracks without an id are told apart by their query.
+func duplicateKey(track *audiotype.TrackData) string {
+	if track.ID != "" {
+		return track.ID
+	}
+
+	return track.Query
+}
+
 func (m *PlayerCog) shuffle(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
 	isInVoiceChannel, err := m.verifyInChannelAndSendError(session, interaction)
 	if err != nil {
@@ -681,6 +1506,81 @@ func (m *PlayerCog) swap(session *discordgo.Session, interaction *discordgo.Inte
 	return nil
 }
 
+func (m *PlayerCog) move(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
+	isInVoiceChannel, err := m.verifyInChannelAndSendError(session, interaction)
+	if err != nil {
+		return fmt.Errorf("verifying user is in voice channel: %w", err)
+	}
+
+	if !isInVoiceChannel {
+		return nil
+	}
+
+	guildPlayer, ok := m.guildVoiceStates[interaction.GuildID]
+	if !ok || guildPlayer.isEmptyQueue() || guildPlayer.remainingQueueLength() == 0 {
+		invalidUsageEmbed := embeds.ErrorMessageEmbed("You can't move tracks in an empty queue")
+		msgData := util.MessageData{
+			Type:   discordgo.InteractionResponseChannelMessageWithSource,
+			Embeds: invalidUsageEmbed,
+			FlagWrapper: &util.FlagWrapper{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		}
+
+		err := util.SendMessage(session, interaction.Interaction, false, msgData)
+		if err != nil {
+			return fmt.Errorf("interaction response: %w", err)
+		}
+
+		return nil
+	}
+
+	options := interaction.ApplicationCommandData().Options
+	toPosition := int(options[1].IntValue())
+
+	firstPosition, lastPosition, err := parsePositionRange(options[0].StringValue())
+	if err == nil {
+		currentPointer := guildPlayer.getCurrentPointer()
+		err = guildPlayer.move(currentPointer+firstPosition, currentPointer+lastPosition, currentPointer+toPosition)
+	}
+
+	if err != nil {
+		invalidUsageEmbed := embeds.ErrorMessageEmbed("The positions you entered are incorrect, please check the queue and try again")
+		msgData := util.MessageData{
+			Embeds: invalidUsageEmbed,
+			Type:   discordgo.InteractionResponseChannelMessageWithSource,
+			FlagWrapper: &util.FlagWrapper{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		}
+
+		err := util.SendMessage(session, interaction.Interaction, false, msgData)
+		if err != nil {
+			return fmt.Errorf("interaction response: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := guildPlayer.refreshState(session); err != nil {
+		m.logger.Warn("unable to refresh views", zap.Error(err), logger.GuildID(guildPlayer.guildID))
+	}
+
+	message := fmt.Sprintf("**%s** has been moved to position `%d`", guildPlayer.getTrackAtPosition(guildPlayer.getCurrentPointer()+toPosition).TrackName, toPosition)
+	if movedCount := lastPosition - firstPosition + 1; movedCount > 1 {
+		message = fmt.Sprintf("**%d tracks** have been moved to positions `%d-%d`", movedCount, toPosition, toPosition+movedCount-1)
+	}
+
+	if err := util.SendMessage(session, interaction.Interaction, false, util.MessageData{
+		Type:   discordgo.InteractionResponseChannelMessageWithSource,
+		Embeds: embeds.MusicPlayerActionEmbed(message, *interaction.Member),
+	}, util.WithDeletion(30*time.Second, interaction.ChannelID)); err != nil {
+		return fmt.Errorf("sending message: %w", err)
+	}
+
+	return nil
+}
+
 func (m *PlayerCog) spice(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
 	isInVoiceChannel, err := m.verifyInChannelAndSendError(session, interaction)
 	if err != nil {
@@ -729,6 +1629,10 @@ func (m *PlayerCog) spice(session *discordgo.Session, interaction *discordgo.Int
 		return fmt.Errorf("getting recommendations: %w", err)
 	}
 
+	for _, track := range recommendations {
+		track.RequesterID = interaction.Member.User.ID
+	}
+
 	addedPosition := guildPlayer.remainingQueueLength() + 1
 	guildPlayer.addTracks(recommendations...)
 
@@ -836,6 +1740,228 @@ func (m *PlayerCog) playerview(session *discordgo.Session, interaction *discordg
 	return nil
 }
 
+// isStreamURL reports whether the value is a link rather than the name of a radio preset.
+func isStreamURL(value string) bool {
+	parsedURL, err := url.Parse(value)
+
+	return err == nil && (parsedURL.Scheme == "http" || parsedURL.Scheme == "https") && parsedURL.Host != ""
+}
+
+func (m *PlayerCog) radio(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
+	isInVoiceChannel, err := m.verifyInChannelAndSendError(session, interaction)
+	if err != nil {
+		return fmt.Errorf("verifying in voice channel: %w", err)
+	}
+
+	if !isInVoiceChannel {
+		return nil
+	}
+
+	if err := m.joinAndCreateGuildPlayer(session, interaction); err != nil {
+		return fmt.Errorf("joining and creating guild player: %w", err)
+	}
+
+	if err := session.InteractionRespond(interaction.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		return fmt.Errorf("deferring message: %w", err)
+	}
+
+	options := interaction.ApplicationCommandData().Options
+	stationName := options[0].StringValue()
+	guildPlayer := m.guildVoiceStates[interaction.GuildID]
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	presets, err := getRadioPresets(ctx, m.fireStoreClient, interaction.GuildID)
+	if err != nil {
+		return fmt.Errorf("getting radio presets: %w", err)
+	}
+
+	streamURL := stationName
+	preset, isPreset := findRadioPreset(presets, stationName)
+
+	if isPreset {
+		streamURL = preset.URL
+	} else if !isStreamURL(stationName) {
+		msgData := util.MessageData{
+			Embeds: embeds.ErrorMessageEmbed(fmt.Sprintf("There is no radio preset named `%s`, please enter a preset or a link to a stream", stationName)),
+			Type:   discordgo.InteractionResponseChannelMessageWithSource,
+		}
+
+		if err := util.SendMessage(session, interaction.Interaction, true, msgData, util.WithDeletion(10*time.Second, interaction.ChannelID)); err != nil {
+			return fmt.Errorf("sending follow up message: %w", err)
+		}
+
+		return nil
+	}
+
+	station, err := radio.Resolve(ctx, streamURL)
+	if err != nil {
+		m.logger.Warn("unable to resolve radio stream", zap.Error(err), logger.GuildID(interaction.GuildID))
+
+		msgData := util.MessageData{
+			Embeds: embeds.ErrorMessageEmbed("Could not tune in to that station, please make sure the link points at a live audio stream"),
+			Type:   discordgo.InteractionResponseChannelMessageWithSource,
+		}
+
+		if err := util.SendMessage(session, interaction.Interaction, true, msgData, util.WithDeletion(10*time.Second, interaction.ChannelID)); err != nil {
+			return fmt.Errorf("sending follow up message: %w", err)
+		}
+
+		return nil
+	}
+
+	if isPreset {
+		station.Name = preset.Name
+	}
+
+	trackData := &audiotype.Data{
+		Tracks: []*audiotype.TrackData{
+			{
+				TrackName: station.Name,
+				Query:     station.URL,
+				Requester: interaction.Member.User.Username,
+				ID:        station.URL,
+				AudioType: audiotype.LiveRadio,
+			},
+		},
+		Type: audiotype.LiveRadio,
+		ID:   station.URL,
+	}
+
+	if err := m.addToQueue(session, interaction, trackData, guildPlayer); err != nil {
+		return fmt.Errorf("adding to queue: %w", err)
+	}
+
+	return nil
+}
+
+func (m *PlayerCog) radioSave(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
+	// checking the stream can take a few seconds
+	if err := session.InteractionRespond(interaction.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		return fmt.Errorf("deferring message: %w", err)
+	}
+
+	options := interaction.ApplicationCommandData().Options
+	presetName, streamURL := strings.TrimSpace(options[0].StringValue()), options[1].StringValue()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	if !isStreamURL(streamURL) {
+		msgData := util.MessageData{
+			Embeds: embeds.ErrorMessageEmbed("Please enter a link to a stream"),
+			FlagWrapper: &util.FlagWrapper{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		}
+
+		if err := util.SendMessage(session, interaction.Interaction, true, msgData); err != nil {
+			return fmt.Errorf("sending follow up message: %w", err)
+		}
+
+		return nil
+	}
+
+	if _, err := radio.Resolve(ctx, streamURL); err != nil {
+		m.logger.Warn("unable to resolve radio stream", zap.Error(err), logger.GuildID(interaction.GuildID))
+
+		msgData := util.MessageData{
+			Embeds: embeds.ErrorMessageEmbed("Could not tune in to that station, please make sure the link points at a live audio stream"),
+			FlagWrapper: &util.FlagWrapper{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		}
+
+		if err := util.SendMessage(session, interaction.Interaction, true, msgData); err != nil {
+			return fmt.Errorf("sending follow up message: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := saveRadioPreset(ctx, m.fireStoreClient, interaction.GuildID, presetName, streamURL); err != nil {
+		if errors.Is(err, errTooManyRadioPresets) {
+			msgData := util.MessageData{
+				Embeds: embeds.ErrorMessageEmbed(fmt.Sprintf("This server already has `%d` radio presets, please delete one first", maxRadioPresets)),
+				FlagWrapper: &util.FlagWrapper{
+					Flags: discordgo.MessageFlagsEphemeral,
+				},
+			}
+
+			if err := util.SendMessage(session, interaction.Interaction, true, msgData); err != nil {
+				return fmt.Errorf("sending follow up message: %w", err)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("saving radio preset for guild (%s): %w", interaction.GuildID, err)
+	}
+
+	msgData := util.MessageData{
+		Embeds: embeds.MusicPlayerActionEmbed(fmt.Sprintf("📻 ***Saved radio preset `%s`*** 👍", presetName), *interaction.Member),
+		FlagWrapper: &util.FlagWrapper{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	}
+
+	if err := util.SendMessage(session, interaction.Interaction, true, msgData); err != nil {
+		return fmt.Errorf("sending follow up message: %w", err)
+	}
+
+	return nil
+}
+
+func (m *PlayerCog) radioDelete(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	options := interaction.ApplicationCommandData().Options
+	presetName := options[0].StringValue()
+
+	if err := deleteRadioPreset(ctx, m.fireStoreClient, interaction.GuildID, presetName); err != nil {
+		if errors.Is(err, errRadioPresetDoesNotExist) {
+			msgData := util.MessageData{
+				Embeds: embeds.ErrorMessageEmbed(fmt.Sprintf("Radio preset `%s` does not exist", presetName)),
+				FlagWrapper: &util.FlagWrapper{
+					Flags: discordgo.MessageFlagsEphemeral,
+				},
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+			}
+
+			if err := util.SendMessage(session, interaction.Interaction, false, msgData); err != nil {
+				return fmt.Errorf("interaction response: %w", err)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("deleting radio preset for guild (%s): %w", interaction.GuildID, err)
+	}
+
+	msgData := util.MessageData{
+		Embeds: embeds.MusicPlayerActionEmbed(fmt.Sprintf("🗑️ ***Deleted radio preset `%s`*** 👍", presetName), *interaction.Member),
+		FlagWrapper: &util.FlagWrapper{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+	}
+
+	if err := util.SendMessage(session, interaction.Interaction, false, msgData); err != nil {
+		return fmt.Errorf("interaction response: %w", err)
+	}
+
+	return nil
+}
+
 func (m *PlayerCog) playlistCreate(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
 	options := interaction.ApplicationCommandData().Options
 	playlistName := options[0].StringValue()
@@ -912,7 +2038,21 @@ func (m *PlayerCog) playlistAdd(session *discordgo.Session, interaction *discord
 		return fmt.Errorf("determining audio type: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*7)
+	if audiotype.IsDiscordAttachment(query) {
+		expiringLinkEmbed := embeds.ErrorMessageEmbed("Discord attachment links expire after a while, so they can't be added to playlists.")
+		msgData := util.MessageData{
+			Embeds: expiringLinkEmbed,
+			Type:   discordgo.InteractionResponseChannelMessageWithSource,
+		}
+
+		if err := util.SendMessage(session, interaction.Interaction, true, msgData, util.WithDeletion(10*time.Second, interaction.ChannelID)); err != nil {
+			return fmt.Errorf("sending follow up message: %w", err)
+		}
+
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), max(time.Second*7, m.retrievalTimeout(audioType)))
 	defer cancel()
 
 	userID := interaction.Member.User.ID
@@ -1088,6 +2228,10 @@ func (m *PlayerCog) help(session *discordgo.Session, interaction *discordgo.Inte
 		return command.CommandConfiguration
 	})
 
+	slices.SortFunc(commands, func(a, b *discordgo.ApplicationCommand) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
 	msgData := util.MessageData{
 		Embeds: embeds.HelpMenuEmbed(commands),
 		Type:   discordgo.InteractionResponseChannelMessageWithSource,
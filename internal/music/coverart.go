@@ -0,0 +1,82 @@
+package music
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiofile"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// the artwork is extracted while building the now playing view, so it mustn't hold it up for long
+const coverArtTimeout time.Duration = 5 * time.Second
+
+// trackCover is the artwork embedded in a track, art is nil when the track has none.
+type trackCover struct {
+	track *audiotype.TrackData
+	art   *audiofile.CoverArt
+}
+
+// coverArtFile returns the artwork to attach to the now playing message, only library
+// tracks have artwork that isn't hosted at a url. The artwork of the last track shown
+// is kept, so it's extracted once rather than on every refresh of the view.
+func (g *guildPlayer) coverArtFile(track *audiotype.TrackData) *discordgo.File {
+	if track.AudioType != audiotype.LibraryTrack {
+		return nil
+	}
+
+	g.mu.RLock()
+	cover := g.cover
+	g.mu.RUnlock()
+
+	if cover == nil || cover.track != track {
+		ctx, cancel := context.WithTimeout(context.Background(), coverArtTimeout)
+		defer cancel()
+
+		art, err := audiofile.ExtractCoverArt(ctx, track.Query)
+		if err != nil && !errors.Is(err, audiofile.ErrNoCoverArt) {
+			g.logger.Warn("unable to extract cover art", zap.Error(err), zap.String("track_id", track.ID))
+		}
+
+		cover = &trackCover{track: track, art: art}
+
+		g.mu.Lock()
+		g.cover = cover
+		g.mu.Unlock()
+	}
+
+	if cover.art == nil {
+		return nil
+	}
+
+	return &discordgo.File{
+		Name:        coverArtFileName(track, cover.art.ContentType),
+		ContentType: cover.art.ContentType,
+		Reader:      bytes.NewReader(cover.art.Data),
+	}
+}
+
+// coverArtFileName names the artwork after its track, which lets the view keep the
+// attachment instead of uploading it again while the same track is playing.
+func coverArtFileName(track *audiotype.TrackData, contentType string) string {
+	extension := ".jpg"
+
+	switch contentType {
+	case "image/png":
+		extension = ".png"
+	case "image/gif":
+		extension = ".gif"
+	case "image/webp":
+		extension = ".webp"
+	}
+
+	sum := sha1.Sum([]byte(track.Query))
+
+	return "cover-" + hex.EncodeToString(sum[:8]) + extension
+}
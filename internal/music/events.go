@@ -9,6 +9,7 @@ import (
 	"github.com/TeddyKahwaji/spice-tunes-go/internal/embeds"
 	"github.com/TeddyKahwaji/spice-tunes-go/internal/logger"
 	"github.com/TeddyKahwaji/spice-tunes-go/internal/util"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
 	"github.com/bwmarrin/discordgo"
 	"go.uber.org/zap"
 )
@@ -18,6 +19,10 @@ const (
 )
 
 func (m *PlayerCog) guildDeleteEvent(_ *discordgo.Session, guildDeleteEvent *discordgo.GuildDelete) {
+	if guildPlayer, ok := m.guildVoiceStates[guildDeleteEvent.ID]; ok {
+		guildPlayer.discardPrefetch()
+	}
+
 	delete(m.guildVoiceStates, guildDeleteEvent.ID)
 	m.logger.Info("bot has been kicked from guild", logger.GuildID(guildDeleteEvent.ID))
 }
@@ -27,13 +32,23 @@ func (m *PlayerCog) handleAutocomplete(session *discordgo.Session, interaction *
 		return
 	}
 
-	const playlistNameOption = "playlist_name"
-	option := interaction.ApplicationCommandData().Options[0]
+	const (
+		playlistNameOption    = "playlist_name"
+		radioStationOption    = "station"
+		radioPresetNameOption = "preset_name"
+		queryOption           = "query"
+	)
+
+	option := focusedOption(interaction.ApplicationCommandData().Options)
+	query := option.StringValue()
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
-	if option.Name == playlistNameOption {
+	suggestions := []*discordgo.ApplicationCommandOptionChoice{}
+
+	switch option.Name {
+	case playlistNameOption:
 		userID := interaction.Member.User.ID
 		playlists, err := m.userPlaylistRetriever.getUserPlaylists(ctx, userID)
 		if err != nil && !errors.Is(err, errNoPlaylistsCreated) {
@@ -41,9 +56,6 @@ func (m *PlayerCog) handleAutocomplete(session *discordgo.Session, interaction *
 			return
 		}
 
-		query := option.StringValue()
-
-		var suggestions []*discordgo.ApplicationCommandOptionChoice
 		for _, playlist := range playlists.Playlists {
 			if strings.Contains(strings.ToLower(playlist.Name), strings.ToLower(query)) {
 				suggestions = append(suggestions, &discordgo.ApplicationCommandOptionChoice{
@@ -53,18 +65,94 @@ func (m *PlayerCog) handleAutocomplete(session *discordgo.Session, interaction *
 			}
 		}
 
-		// Respond with the suggestions
-		err = session.InteractionRespond(interaction.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionApplicationCommandAutocompleteResult,
-			Data: &discordgo.InteractionResponseData{
-				Choices: suggestions,
-			},
-		})
+	case radioStationOption, radioPresetNameOption:
+		presets, err := getRadioPresets(ctx, m.fireStoreClient, interaction.GuildID)
 		if err != nil {
-			m.logger.Warn("Sending auto-complete interaction", zap.Error(err))
+			m.logger.Warn("Could not retrieve radio presets", logger.GuildID(interaction.GuildID), zap.Error(err))
 			return
 		}
+
+		for _, preset := range presets {
+			if strings.Contains(strings.ToLower(preset.Name), strings.ToLower(query)) {
+				suggestions = append(suggestions, &discordgo.ApplicationCommandOptionChoice{
+					Name:  preset.Name,
+					Value: preset.Name,
+				})
+			}
+		}
+
+	case queryOption:
+		if strings.HasPrefix(strings.ToLower(query), audiotype.LibraryPrefix) {
+			suggestions = m.librarySuggestions(query)
+			break
+		}
+
+		var ok bool
+		if suggestions, ok = m.playSuggestions(interaction, query); !ok {
+			return
+		}
+
+	default:
+		return
+	}
+
+	// Respond with the suggestions
+	err := session.InteractionRespond(interaction.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: suggestions,
+		},
+	})
+	if err != nil {
+		m.logger.Warn("Sending auto-complete interaction", zap.Error(err))
+		return
+	}
+}
+
+// focusedOption returns the option the user is typing in, which isn't always the first one.
+func focusedOption(options []*discordgo.ApplicationCommandInteractionDataOption) *discordgo.ApplicationCommandInteractionDataOption {
+	for _, option := range options {
+		if option.Focused {
+			return option
+		}
 	}
+
+	return options[0]
+}
+
+// librarySuggestions suggests library tracks once the query starts with the library prefix.
+func (m *PlayerCog) librarySuggestions(query string) []*discordgo.ApplicationCommandOptionChoice {
+	suggestions := []*discordgo.ApplicationCommandOptionChoice{}
+
+	if m.library == nil || !strings.HasPrefix(strings.ToLower(query), audiotype.LibraryPrefix) {
+		return suggestions
+	}
+
+	for _, track := range m.library.Search(query[len(audiotype.LibraryPrefix):], maxChoices) {
+		// tracks are referred to by path, unless it is too long to fit in which case
+		// as much of the name is searched for as fits
+		value := audiotype.LibraryPrefix + track.Path
+		if len(value) > maxChoiceValue {
+			value = truncateChoiceValue(audiotype.LibraryPrefix + track.Name())
+		}
+
+		suggestions = append(suggestions, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateChoiceName(track.Name()),
+			Value: value,
+		})
+	}
+
+	return suggestions
+}
+
+func truncateChoiceName(name string) string {
+	const maxLength = 100
+
+	if runes := []rune(name); len(runes) > maxLength {
+		return string(runes[:maxLength-3]) + "..."
+	}
+
+	return name
 }
 
 func (m *PlayerCog) voiceStateUpdateEvent(session *discordgo.Session, vc *discordgo.VoiceStateUpdate) {
@@ -96,6 +184,7 @@ func (m *PlayerCog) voiceStateUpdateEvent(session *discordgo.Session, vc *discor
 
 		if guildPlayer, ok := m.guildVoiceStates[vc.GuildID]; ok {
 			guildPlayer.destroyAllViews(session)
+			guildPlayer.discardPrefetch()
 			delete(m.guildVoiceStates, vc.GuildID)
 		}
 	}
@@ -0,0 +1,69 @@
+package music
+
+import (
+	"sync"
+	"time"
+)
+
+// expiringCache is a size bounded map whose entries are dropped after a while.
+type expiringCache[V any] struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]expiringEntry[V]
+}
+
+type expiringEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+func newExpiringCache[V any](ttl time.Duration, maxEntries int) *expiringCache[V] {
+	return &expiringCache[V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]expiringEntry[V]),
+	}
+}
+
+func (c *expiringCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		var zero V
+		return zero, false
+	}
+
+	return entry.value, true
+}
+
+func (c *expiringCache[V]) set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+
+	if len(c.entries) >= c.maxEntries {
+		for key, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+
+		// with nothing expired an arbitrary entry makes room
+		for key := range c.entries {
+			if len(c.entries) < c.maxEntries {
+				break
+			}
+
+			delete(c.entries, key)
+		}
+	}
+
+	c.entries[key] = expiringEntry[V]{
+		value:     value,
+		expiresAt: now.Add(c.ttl),
+	}
+}
@@ -0,0 +1,56 @@
+package music
+
+type audioFilter string
+
+const (
+	noFilter        audioFilter = "None"
+	bassBoostFilter audioFilter = "Bass Boost"
+	nightcoreFilter audioFilter = "Nightcore"
+	vaporwaveFilter audioFilter = "Vaporwave"
+	eightDFilter    audioFilter = "8D"
+	karaokeFilter   audioFilter = "Karaoke"
+)
+
+type audioFilterPreset struct {
+	// ffmpeg audio filter chain passed to the encoder
+	chain string
+	// playback speed relative to the original track, used to keep
+	// seeking and position tracking aligned with the source audio
+	speed float64
+}
+
+var audioFilterPresets = map[audioFilter]audioFilterPreset{
+	noFilter: {
+		speed: 1,
+	},
+	bassBoostFilter: {
+		chain: "bass=g=12:f=110:w=0.6",
+		speed: 1,
+	},
+	nightcoreFilter: {
+		chain: "aresample=48000,asetrate=48000*1.25,aresample=48000",
+		speed: 1.25,
+	},
+	vaporwaveFilter: {
+		chain: "aresample=48000,asetrate=48000*0.8,aresample=48000",
+		speed: 0.8,
+	},
+	eightDFilter: {
+		chain: "apulsator=hz=0.08",
+		speed: 1,
+	},
+	karaokeFilter: {
+		chain: "stereotools=mlev=0.015625",
+		speed: 1,
+	},
+}
+
+// audioFilterOrder is the order presets are offered to users in.
+var audioFilterOrder = []audioFilter{
+	bassBoostFilter,
+	nightcoreFilter,
+	vaporwaveFilter,
+	eightDFilter,
+	karaokeFilter,
+	noFilter,
+}
@@ -7,6 +7,7 @@ import (
 	"maps"
 	"math/rand"
 	"slices"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -33,26 +34,56 @@ const (
 	notPlaying voiceState = "NOT_PLAYING"
 )
 
+type loopMode string
+
 const (
-	guildCollection    string = "Guilds"
-	userDataCollection string = "UserData"
-	likedTracksPath    string = "LikedTracks"
+	loopOff   loopMode = "Off"
+	loopTrack loopMode = "Track"
+	loopQueue loopMode = "Queue"
 )
 
 const (
-	paginationSeparator int = 8
+	guildCollection      string = "Guilds"
+	userDataCollection   string = "UserData"
+	likedTracksPath      string = "LikedTracks"
+	volumePath           string = "Volume"
+	podcastPositionsPath string = "PodcastPositions"
+	queueJumpSelectID    string = "QueueJumpSelect"
 )
 
+const (
+	paginationSeparator int           = 8
+	seekButtonInterval  time.Duration = 15 * time.Second
+	defaultVolume       int           = 100
+	maxVolume           int           = 200
+	maxRadioReconnects  int           = 5
+	radioReconnectDelay time.Duration = 2 * time.Second
+	// streams that played at least this long before dropping are considered recovered
+	stableRadioStream time.Duration = time.Minute
+)
+
+// endOfQueue is the position to insert tracks at to append them.
+const endOfQueue int = -1
+
 var (
 	errStreamNonExistent = errors.New("no stream exists")
 	errUserHasNoLikes    = errors.New("user has no likes")
 	errNoViews           = errors.New("guild player does not have any views")
 	errEmptyQueue        = errors.New("queue is empty")
 	errInvalidPosition   = errors.New("position provided is out of bounds")
+	errInvalidSeek       = errors.New("seek position is beyond the length of the track")
+	errInvalidVolume     = errors.New("volume provided is out of bounds")
+	errUnknownFilter     = errors.New("filter provided is not a supported preset")
 )
 
 type userData struct {
 	LikedTracks []*audiotype.TrackData `firestore:"LikedTracks"`
+	// PodcastPositions holds how far into each podcast episode the user got, in seconds
+	PodcastPositions map[string]int64 `firestore:"PodcastPositions,omitempty"`
+}
+
+type guildSettings struct {
+	Volume *int `firestore:"Volume"`
 }
 
 type supportedView string
@@ -75,15 +106,29 @@ type guildPlayer struct {
 	voiceClient     *discordgo.VoiceConnection
 	queue           []*audiotype.TrackData
 	voiceState      voiceState
+	loopMode        loopMode
+	volume          int
+	filter          audioFilter
 	queuePtr        atomic.Int32
 	stream          *dca.StreamingSession
+	startOffset     time.Duration
+	seekRequested   bool
+	restartPaused   bool
+	playbackSpeed   float64
+	streamTitle     string
+	streamTrack     *audiotype.TrackData
+	radioReconnects int
 	doneChannel     chan error
 	stopChannel     chan bool
 	views           map[*guildView]struct{}
 	fireStoreClient FireStore
+	preparer        trackPreparer
+	prefetchMu      sync.Mutex
+	prefetch        *prefetchedTrack
+	cover           *trackCover
 }
 
-func newGuildPlayer(vc *discordgo.VoiceConnection, channelID string, fireStoreClient FireStore, logger *zap.Logger) *guildPlayer {
+func newGuildPlayer(vc *discordgo.VoiceConnection, channelID string, fireStoreClient FireStore, preparer trackPreparer, logger *zap.Logger) *guildPlayer {
 	return &guildPlayer{
 		voiceClient:     vc,
 		guildID:         vc.GuildID,
@@ -92,8 +137,13 @@ func newGuildPlayer(vc *discordgo.VoiceConnection, channelID string, fireStoreCl
 		views:           make(map[*guildView]struct{}),
 		logger:          logger,
 		voiceState:      notPlaying,
+		loopMode:        loopOff,
+		volume:          defaultVolume,
+		filter:          noFilter,
+		playbackSpeed:   1,
 		stopChannel:     make(chan bool),
 		fireStoreClient: fireStoreClient,
+		preparer:        preparer,
 	}
 }
 
@@ -126,15 +176,47 @@ func (g *guildPlayer) getMusicPlayerViewConfig() *views.Config {
 		})
 	}
 
-	musicPlayerEmbed.Thumbnail = &discordgo.MessageEmbedThumbnail{
-		URL: currentTrack.TrackImageURL,
+	if filter := g.getFilter(); filter != noFilter {
+		musicPlayerEmbed.Fields = append(musicPlayerEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:   "`Filter:`",
+			Value:  string(filter),
+			Inline: true,
+		})
+	}
+
+	if g.isLooping() {
+		musicPlayerEmbed.Fields = append(musicPlayerEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:   "`Loop:`",
+			Value:  string(g.getLoopMode()),
+			Inline: true,
+		})
+	}
+
+	if streamTitle := g.getStreamTitle(); streamTitle != "" {
+		musicPlayerEmbed.Fields = append(musicPlayerEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  "`On Air:`",
+			Value: streamTitle,
+		})
+	}
+
+	musicPlayerEmbed.Thumbnail = embeds.Thumbnail(currentTrack.TrackImageURL)
+
+	var files []*discordgo.File
+
+	// artwork embedded in library tracks is attached to the message for the embed to show
+	if coverArt := g.coverArtFile(currentTrack); coverArt != nil {
+		musicPlayerEmbed.Thumbnail = embeds.Thumbnail("attachment://" + coverArt.Name)
+		files = append(files, coverArt)
 	}
 
 	buttonsConfig := embeds.MusicPlayButtonsConfig{
-		SkipDisabled:  !g.hasNext() || g.isPaused(),
+		SkipDisabled:  !g.canSkip() || g.isPaused(),
 		BackDisabled:  !g.hasPrevious() || g.isPaused(),
 		ClearDisabled: !g.hasNext(),
+		SeekDisabled:  g.isPaused() || g.isLive(),
 		Resume:        g.isPaused(),
+		LoopLabel:     "Loop: " + string(g.getLoopMode()),
+		LoopActive:    g.isLooping(),
 	}
 
 	musicPlayerButtons := embeds.GetMusicPlayerButtons(buttonsConfig)
@@ -144,6 +226,7 @@ func (g *guildPlayer) getMusicPlayerViewConfig() *views.Config {
 			MessageComponents: musicPlayerButtons,
 		},
 		Embeds: []*discordgo.MessageEmbed{musicPlayerEmbed},
+		Files:  files,
 	}
 }
 
@@ -153,6 +236,25 @@ func (g *guildPlayer) likeCurrentSong(ctx context.Context, session *discordgo.Se
 	userID := interaction.Member.User.ID
 	currentSong := g.getCurrentSong()
 
+	if audiotype.IsDiscordAttachment(currentSong.Query) {
+		if err := session.InteractionRespond(interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+		}); err != nil {
+			return fmt.Errorf("sending update message: %w", err)
+		}
+
+		if err := util.SendMessage(session, interaction, true, util.MessageData{
+			Embeds: embeds.ErrorMessageEmbed("Discord attachment links expire after a while, so they can't be liked."),
+			FlagWrapper: &util.FlagWrapper{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		}); err != nil {
+			return fmt.Errorf("sending expiring link message: %w", err)
+		}
+
+		return nil
+	}
+
 	docRef, err := g.fireStoreClient.GetDocumentFromCollection(ctx, guildCollection, g.guildID).
 		Collection(userDataCollection).
 		Doc(userID).
@@ -204,10 +306,96 @@ func (g *guildPlayer) getQueuePaginationConfig() (*pagination.PaginationConfig[a
 	}
 
 	paginationConfig := pagination.NewPaginatedConfig(g.queue[g.getCurrentPointer()+1:], paginationSeparator)
+	paginationConfig.SetPageComponents(queueJumpSelectMenu)
 
 	return paginationConfig, nil
 }
 
+// queueJumpSelectMenu lets the tracks of a queue page be jumped to, its values are the
+// positions of the tracks counted from the current one.
+func queueJumpSelectMenu(tracks []*audiotype.TrackData, pageNum int, separator int) []discordgo.MessageComponent {
+	firstPosition := (pageNum-1)*separator + 1
+
+	options := make([]discordgo.SelectMenuOption, 0, len(tracks))
+	for i, track := range tracks {
+		label := fmt.Sprintf("%d: %s", firstPosition+i, track.TrackName)
+		if len([]rune(label)) > 100 {
+			label = string([]rune(label)[:97]) + "..."
+		}
+
+		options = append(options, discordgo.SelectMenuOption{
+			Label: label,
+			Value: strconv.Itoa(firstPosition + i),
+		})
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    queueJumpSelectID,
+					Placeholder: "Jump to a track",
+					Options:     options,
+				},
+			},
+		},
+	}
+}
+
+// jumpFromQueueView skips to the track picked from a queue view's select menu. The
+// views are refreshed once the track starts playing.
+func (g *guildPlayer) jumpFromQueueView(session *discordgo.Session, interaction *discordgo.Interaction) error {
+	values := interaction.MessageComponentData().Values
+	if len(values) == 0 {
+		return nil
+	}
+
+	position, err := strconv.Atoi(values[0])
+	if err != nil {
+		return fmt.Errorf("parsing queue position: %w", err)
+	}
+
+	// nothing would receive the stop signal while the player is idle
+	if g.isNotActive() {
+		if err := session.InteractionRespond(interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Embeds: []*discordgo.MessageEmbed{embeds.ErrorMessageEmbed("Nothing is playing in this server")},
+				Flags:  discordgo.MessageFlagsEphemeral,
+			},
+		}); err != nil {
+			return fmt.Errorf("sending ephemeral message: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := g.skipTo(g.getCurrentPointer() + position); err != nil {
+		return fmt.Errorf("skipping to position %d: %w", position, err)
+	}
+
+	track := g.getCurrentSong()
+
+	if err := session.InteractionRespond(interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	}); err != nil {
+		return fmt.Errorf("deferring message update: %w", err)
+	}
+
+	g.sendStopSignal()
+
+	message, err := session.ChannelMessageSendEmbed(interaction.ChannelID, embeds.MusicPlayerActionEmbed(fmt.Sprintf("⏩ **Skipped to %s** 👍", track.TrackName), *interaction.Member))
+	if err != nil {
+		return fmt.Errorf("sending action initiated message: %w", err)
+	}
+
+	if err := util.DeleteMessageAfterTime(session, interaction.ChannelID, message.ID, 30*time.Second); err != nil {
+		return fmt.Errorf("deleting message after time: %w", err)
+	}
+
+	return nil
+}
+
 func (g *guildPlayer) generateMusicQueueView(interaction *discordgo.Interaction, session *discordgo.Session) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -229,6 +417,10 @@ func (g *guildPlayer) generateMusicQueueView(interaction *discordgo.Interaction,
 	viewConfig := paginationConfig.GetViewConfig(getQueueEmbed)
 
 	handler := func(passedInteraction *discordgo.Interaction) error {
+		if passedInteraction.MessageComponentData().CustomID == queueJumpSelectID {
+			return g.jumpFromQueueView(session, passedInteraction)
+		}
+
 		messageID := passedInteraction.Message.ID
 		paginationConfig.UpdateData(g.queue[g.getCurrentPointer()+1:], paginationSeparator)
 
@@ -309,6 +501,20 @@ func (g *guildPlayer) generateMusicPlayerView(interaction *discordgo.Interaction
 		case "ClearBtn":
 			g.clearUpcomingTracks()
 			actionMessage = "💥 **Cleared...** ⏹"
+		case "SeekBackBtn":
+			if err := g.seekBy(-seekButtonInterval); err != nil {
+				return fmt.Errorf("seeking backwards: %w", err)
+			}
+
+			actionMessage = "⏪ **Jumped back 15 seconds** 👍"
+		case "SeekForwardBtn":
+			if err := g.seekBy(seekButtonInterval); err != nil {
+				return fmt.Errorf("seeking forwards: %w", err)
+			}
+
+			actionMessage = "⏩ **Jumped forward 15 seconds** 👍"
+		case "LoopBtn":
+			actionMessage = fmt.Sprintf("🔁 **Loop mode set to %s** 👍", g.cycleLoopMode())
 		case "LikeBtn":
 			return g.likeCurrentSong(ctx, session, passedInteraction)
 		}
@@ -366,7 +572,13 @@ func (g *guildPlayer) generateMusicPlayerView(interaction *discordgo.Interaction
 }
 
 func (g *guildPlayer) getLikes(ctx context.Context, userID string) ([]*audiotype.TrackData, error) {
-	docRef, err := g.fireStoreClient.GetDocumentFromCollection(ctx, guildCollection, g.guildID).
+	return getUserLikes(ctx, g.fireStoreClient, g.guildID, userID)
+}
+
+// getUserLikes returns the tracks the user liked in the guild, which is also needed
+// before the guild has a player.
+func getUserLikes(ctx context.Context, fs FireStore, guildID string, userID string) ([]*audiotype.TrackData, error) {
+	docRef, err := fs.GetDocumentFromCollection(ctx, guildCollection, guildID).
 		Collection(userDataCollection).
 		Doc(userID).
 		Get(ctx)
@@ -475,10 +687,41 @@ func (g *guildPlayer) removeTrack(position int) (*audiotype.TrackData, error) {
 
 	track := g.queue[position]
 	g.queue = append(g.queue[:position], g.queue[position+1:]...)
+	g.invalidatePrefetch()
 
 	return track, nil
 }
 
+// removeUpcomingTracks removes the tracks after the current one that match, which are
+// given their position counted from the current track. The removed tracks are returned in queue order.
+func (g *guildPlayer) removeUpcomingTracks(matches func(position int, track *audiotype.TrackData) bool) []*audiotype.TrackData {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	currentPointer := g.getCurrentPointer()
+	if currentPointer >= len(g.queue) {
+		return nil
+	}
+
+	kept := slices.Clone(g.queue[:currentPointer+1])
+	removed := []*audiotype.TrackData{}
+
+	for i, track := range g.queue[currentPointer+1:] {
+		if matches(i+1, track) {
+			removed = append(removed, track)
+		} else {
+			kept = append(kept, track)
+		}
+	}
+
+	if len(removed) > 0 {
+		g.queue = kept
+		g.invalidatePrefetch()
+	}
+
+	return removed
+}
+
 func (g *guildPlayer) isValidPosition(position int) bool {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -493,6 +736,7 @@ func (g *guildPlayer) clearUpcomingTracks() {
 	defer g.mu.Unlock()
 	g.queue = g.queue[:1]
 	g.queuePtr.Store(0)
+	g.invalidatePrefetch()
 }
 
 func (g *guildPlayer) resetQueue() {
@@ -500,6 +744,7 @@ func (g *guildPlayer) resetQueue() {
 	defer g.mu.Unlock()
 	g.queue = g.queue[:0]
 	g.queuePtr.Store(0)
+	g.invalidatePrefetch()
 }
 
 func (g *guildPlayer) setVoiceState(voiceState voiceState) {
@@ -525,6 +770,42 @@ func (g *guildPlayer) swap(firstPosition int, secondPosition int) error {
 	defer g.mu.Unlock()
 
 	g.queue[firstPosition], g.queue[secondPosition] = g.queue[secondPosition], g.queue[firstPosition]
+	g.invalidatePrefetch()
+
+	return nil
+}
+
+// move shifts the tracks from first to last, inclusive, so the first of them ends up at
+// position while everything else keeps its order. Like swap it takes queue indexes.
+func (g *guildPlayer) move(first int, last int, position int) error {
+	lastPosition := position + last - first
+
+	isInvalidPositions := first > last || !g.isValidPosition(first) || !g.isValidPosition(last) || !g.isValidPosition(lastPosition)
+	isBeforeQueuePtr := g.isBeforeQueuePtr(first) || g.isBeforeQueuePtr(position)
+	isCurrentTrack := first == g.getCurrentPointer() || position == g.getCurrentPointer() // the track currently playing can't be moved, nor can tracks be moved before it
+
+	if isInvalidPositions || isBeforeQueuePtr || isCurrentTrack {
+		return errInvalidPosition
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	moved := slices.Clone(g.queue[first : last+1])
+	g.queue = slices.Insert(slices.Delete(g.queue, first, last+1), position, moved...)
+	g.invalidatePrefetch()
+
+	return nil
+}
+
+// skipTo jumps to the track at the queue index. The tracks jumped over stay in the queue
+// behind it, so rewinding still goes back through them.
+func (g *guildPlayer) skipTo(position int) error {
+	if !g.isValidPosition(position) || position <= g.getCurrentPointer() {
+		return errInvalidPosition
+	}
+
+	g.queuePtr.Store(int32(position))
 
 	return nil
 }
@@ -549,6 +830,30 @@ func (g *guildPlayer) addTracks(data ...*audiotype.TrackData) {
 	defer g.mu.Unlock()
 
 	g.queue = append(g.queue, data...)
+	g.invalidatePrefetch()
+}
+
+// insertTracks adds the tracks as one block at a position counted from the current track,
+// 1 being right after it. Positions outside the queue append the tracks instead, the
+// position they ended up at is returned.
+func (g *guildPlayer) insertTracks(position int, data ...*audiotype.TrackData) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	currentPointer := g.getCurrentPointer()
+	endPosition := len(g.queue) - currentPointer
+
+	if len(g.queue) == 0 || position < 1 || position > endPosition {
+		g.queue = append(g.queue, data...)
+		g.invalidatePrefetch()
+
+		return max(endPosition, 1)
+	}
+
+	g.queue = slices.Insert(g.queue, currentPointer+position, data...)
+	g.invalidatePrefetch()
+
+	return position
 }
 
 func (g *guildPlayer) hasNext() bool {
@@ -581,6 +886,8 @@ func (g *guildPlayer) shuffleQueue() {
 		j := rand.Intn(i-g.getCurrentPointer()) + g.getCurrentPointer() + 1
 		g.queue[i], g.queue[j] = g.queue[j], g.queue[i]
 	}
+
+	g.invalidatePrefetch()
 }
 
 func (g *guildPlayer) pause() error {
@@ -629,9 +936,57 @@ func (g *guildPlayer) hasPrevious() bool {
 }
 
 func (g *guildPlayer) skip() {
+	// when looping the queue, skipping the last track wraps back to the start
+	if !g.hasNext() && g.getLoopMode() == loopQueue {
+		g.queuePtr.Store(0)
+		return
+	}
+
 	_ = g.queuePtr.Add(1)
 }
 
+// canSkip returns true when there is a track to skip to, either the next one
+// in the queue or the first one when the queue is looping.
+func (g *guildPlayer) canSkip() bool {
+	return g.hasNext() || g.getLoopMode() == loopQueue
+}
+
+func (g *guildPlayer) isLooping() bool {
+	return g.getLoopMode() != loopOff
+}
+
+func (g *guildPlayer) getLoopMode() loopMode {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.loopMode
+}
+
+func (g *guildPlayer) setLoopMode(mode loopMode) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.loopMode = mode
+}
+
+// cycleLoopMode advances the loop mode in the order off -> track -> queue -> off
+// and returns the newly active mode.
+func (g *guildPlayer) cycleLoopMode() loopMode {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	switch g.loopMode {
+	case loopOff:
+		g.loopMode = loopTrack
+	case loopTrack:
+		g.loopMode = loopQueue
+	default:
+		g.loopMode = loopOff
+	}
+
+	return g.loopMode
+}
+
 func (g *guildPlayer) sendStopSignal() {
 	g.stopChannel <- true
 }
@@ -639,3 +994,285 @@ func (g *guildPlayer) sendStopSignal() {
 func (g *guildPlayer) rewind() {
 	_ = g.queuePtr.Add(-1)
 }
+
+// currentPosition returns how far into the current track playback is,
+// including the offset the current encode was started from.
+func (g *guildPlayer) currentPosition() time.Duration {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.stream == nil {
+		return g.startOffset
+	}
+
+	// filters can change the speed of the encoded audio, so the elapsed
+	// playback time is scaled back to the position within the source track
+	elapsed := time.Duration(float64(g.stream.PlaybackPosition()) * g.playbackSpeed)
+
+	return g.startOffset + elapsed
+}
+
+// seek restarts the current track from the provided position, which must lie within the track.
+func (g *guildPlayer) seek(position time.Duration) error {
+	currentTrack := g.getCurrentSong()
+	if position < 0 || position >= currentTrack.Duration {
+		return errInvalidSeek
+	}
+
+	g.restartFrom(position)
+
+	return nil
+}
+
+// restartFrom restarts the current track from the position without checking it against
+// the length of the track, a paused track stays paused once the new encode starts.
+func (g *guildPlayer) restartFrom(position time.Duration) {
+	g.mu.Lock()
+	g.startOffset = position
+	g.seekRequested = true
+	g.restartPaused = g.voiceState == paused
+	g.mu.Unlock()
+
+	g.sendStopSignal()
+}
+
+// seekBy moves the current track forwards or backwards by the provided amount,
+// clamping the result to the bounds of the track.
+func (g *guildPlayer) seekBy(delta time.Duration) error {
+	position := max(g.currentPosition()+delta, 0)
+	if trackLength := g.getCurrentSong().Duration; position >= trackLength {
+		position = max(trackLength-time.Second, 0)
+	}
+
+	return g.seek(position)
+}
+
+// takeStartOffset returns the offset the next encode should start from and
+// whether it was requested through a seek. Offsets only carry over when a seek
+// was requested, any other restart of the player begins the track from the start.
+func (g *guildPlayer) takeStartOffset() (time.Duration, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	seeked := g.seekRequested
+	if !seeked {
+		g.startOffset = 0
+	}
+
+	g.seekRequested = false
+
+	return g.startOffset, seeked
+}
+
+// takeRestartPaused reports whether the encode about to begin replaces one that was paused.
+func (g *guildPlayer) takeRestartPaused() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	restartPaused := g.restartPaused
+	g.restartPaused = false
+
+	return restartPaused
+}
+
+// isRestarting reports whether the current track is about to be restarted from a sought position.
+func (g *guildPlayer) isRestarting() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.seekRequested
+}
+
+// resumeFrom starts the encode about to begin from the position, such as where
+// a listener left off in a podcast episode.
+func (g *guildPlayer) resumeFrom(position time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.startOffset = position
+}
+
+// loadSettings applies the guild's persisted settings to the player,
+// guilds without any saved settings keep the defaults.
+func (g *guildPlayer) loadSettings(ctx context.Context) error {
+	doc, err := g.fireStoreClient.GetDocumentFromCollection(ctx, guildCollection, g.guildID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+
+		return fmt.Errorf("getting guild document: %w", err)
+	}
+
+	var settings guildSettings
+	if err := doc.DataTo(&settings); err != nil {
+		return fmt.Errorf("converting data to guildSettings struct: %w", err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if settings.Volume != nil {
+		g.volume = *settings.Volume
+	}
+
+	return nil
+}
+
+func (g *guildPlayer) getVolume() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.volume
+}
+
+// setVolume persists the volume for the guild and re-encodes the current track
+// from its current position so the change is heard immediately.
+func (g *guildPlayer) setVolume(ctx context.Context, volume int) error {
+	if err := saveVolume(ctx, g.fireStoreClient, g.guildID, volume); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	g.volume = volume
+	g.mu.Unlock()
+
+	return g.reencode()
+}
+
+// saveVolume persists the volume for the guild, which is also needed before the guild has a player.
+func saveVolume(ctx context.Context, fs FireStore, guildID string, volume int) error {
+	if volume < 0 || volume > maxVolume {
+		return errInvalidVolume
+	}
+
+	if _, err := fs.GetDocumentFromCollection(ctx, guildCollection, guildID).
+		Set(ctx, map[string]interface{}{volumePath: volume}, firestore.MergeAll); err != nil {
+		return fmt.Errorf("saving volume: %w", err)
+	}
+
+	return nil
+}
+
+// reencode restarts the current track from its current position, picking up
+// any changes made to the encoding options.
+func (g *guildPlayer) reencode() error {
+	if g.isNotActive() || g.isQueueDepleted() {
+		return nil
+	}
+
+	// live streams have no position to return to, they simply reconnect
+	if g.isLive() {
+		g.mu.Lock()
+		g.restartPaused = g.voiceState == paused
+		g.mu.Unlock()
+
+		g.sendStopSignal()
+
+		return nil
+	}
+
+	// tracks of unknown length can't be bounds checked, so they restart wherever they are
+	if g.getCurrentSong().Duration == 0 {
+		g.restartFrom(g.currentPosition())
+		return nil
+	}
+
+	if err := g.seek(g.currentPosition()); err != nil && !errors.Is(err, errInvalidSeek) {
+		return fmt.Errorf("seeking to current position: %w", err)
+	}
+
+	return nil
+}
+
+// reloadTrack makes the player pick up a new source for the track, restarting
+// it when it is the track playing and dropping it if it was prefetched.
+func (g *guildPlayer) reloadTrack(track *audiotype.TrackData) error {
+	g.discardPrefetch()
+	g.invalidatePrefetch()
+
+	if g.isNotActive() || g.getCurrentSong() != track {
+		return nil
+	}
+
+	return g.seek(0)
+}
+
+func (g *guildPlayer) isLive() bool {
+	if g.isQueueDepleted() {
+		return false
+	}
+
+	return g.getCurrentSong().AudioType == audiotype.LiveRadio
+}
+
+// setStreamTitle records what the live stream of the track is currently playing.
+func (g *guildPlayer) setStreamTitle(track *audiotype.TrackData, title string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.streamTrack = track
+	g.streamTitle = title
+}
+
+// getStreamTitle returns the title announced by the live stream playing, titles
+// of streams that have since been skipped are ignored.
+func (g *guildPlayer) getStreamTitle() string {
+	if !g.isLive() {
+		return ""
+	}
+
+	currentTrack := g.getCurrentSong()
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.streamTrack != currentTrack {
+		return ""
+	}
+
+	return g.streamTitle
+}
+
+// nextRadioReconnect returns which reconnection attempt is due after a live stream
+// dropped, streams that had been playing for a while start counting afresh.
+func (g *guildPlayer) nextRadioReconnect(streamedFor time.Duration) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if streamedFor >= stableRadioStream {
+		g.radioReconnects = 0
+	}
+
+	g.radioReconnects++
+
+	return g.radioReconnects
+}
+
+func (g *guildPlayer) resetRadioReconnects() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.radioReconnects = 0
+}
+
+func (g *guildPlayer) getFilter() audioFilter {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.filter
+}
+
+// setFilter applies the filter to the rest of the session and re-encodes
+// the current track so the change is heard immediately.
+func (g *guildPlayer) setFilter(filter audioFilter) error {
+	if _, ok := audioFilterPresets[filter]; !ok {
+		return errUnknownFilter
+	}
+
+	g.mu.Lock()
+	g.filter = filter
+	g.mu.Unlock()
+
+	return g.reencode()
+}
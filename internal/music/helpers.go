@@ -8,15 +8,19 @@ import (
 	"maps"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/TeddyKahwaji/spice-tunes-go/internal/embeds"
 	"github.com/TeddyKahwaji/spice-tunes-go/internal/logger"
 	"github.com/TeddyKahwaji/spice-tunes-go/internal/util"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiocache"
 	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
 	"github.com/TeddyKahwaji/spice-tunes-go/pkg/commands"
 	"github.com/TeddyKahwaji/spice-tunes-go/pkg/funcs"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/radio"
 	"github.com/bwmarrin/discordgo"
 	"github.com/jonas747/dca"
 	"github.com/wader/goutubedl"
@@ -52,13 +56,24 @@ func (m *PlayerCog) joinAndCreateGuildPlayer(session *discordgo.Session, interac
 		}
 
 		guildPlayerLogger := m.logger.With(logger.GuildID(interaction.GuildID))
-		m.guildVoiceStates[interaction.GuildID] = newGuildPlayer(channelVoiceConnection, interaction.ChannelID, m.fireStoreClient, guildPlayerLogger)
+		guildPlayer := newGuildPlayer(channelVoiceConnection, interaction.ChannelID, m.fireStoreClient, m.guildTrackPreparer(interaction.GuildID), guildPlayerLogger)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+
+		if err := guildPlayer.loadSettings(ctx); err != nil {
+			guildPlayerLogger.Warn("unable to load guild settings, using defaults", zap.Error(err))
+		}
+
+		m.guildVoiceStates[interaction.GuildID] = guildPlayer
 	}
 
 	return nil
 }
 
-func (m *PlayerCog) downloadTrack(ctx context.Context, audioTrackName string) (*os.File, error) {
+// openDownload starts downloading the track with yt-dlp, the returned result
+// must be closed once the caller is done reading from it.
+func (m *PlayerCog) openDownload(ctx context.Context, audioTrackName string) (*goutubedl.DownloadResult, error) {
 	options := goutubedl.Options{
 		Type:       goutubedl.TypeSingle,
 		HTTPClient: m.httpClient,
@@ -79,11 +94,28 @@ func (m *PlayerCog) downloadTrack(ctx context.Context, audioTrackName string) (*
 		return nil, fmt.Errorf("attempting to download from youtube: %w", err)
 	}
 
+	// links from other sites are checked again, liked and saved tracks are replayed
+	// long after they were added and the permitted extractors may have changed since
+	if audioType, err := audiotype.DetermineAudioType(audioTrackName); err == nil && audioType == audiotype.GenericURL {
+		if err := m.genericWrapper.CheckExtractors(result.Info); err != nil {
+			return nil, err
+		}
+	}
+
 	downloadResult, err := result.DownloadWithOptions(ctx, downloadOptions)
 	if err != nil {
 		return nil, fmt.Errorf("downloading youtube data: %w", err)
 	}
 
+	return downloadResult, nil
+}
+
+func (m *PlayerCog) downloadTrack(ctx context.Context, audioTrackName string) (*os.File, error) {
+	downloadResult, err := m.openDownload(ctx, audioTrackName)
+	if err != nil {
+		return nil, err
+	}
+
 	defer func() {
 		if err := downloadResult.Close(); err != nil {
 			m.logger.Warn("couldn't close downloaded result", zap.Error(err))
@@ -98,6 +130,299 @@ func (m *PlayerCog) downloadTrack(ctx context.Context, audioTrackName string) (*
 	return file, nil
 }
 
+// localTrack is a track available on disk, temporary files are deleted once the
+// track is released while files owned by the audio cache are unpinned.
+type localTrack struct {
+	path      string
+	temporary bool
+	pin       *audiocache.Pin
+}
+
+func newCachedTrack(pin *audiocache.Pin) *localTrack {
+	return &localTrack{path: pin.Path, pin: pin}
+}
+
+func (t *localTrack) release(logger *zap.Logger) {
+	if t.pin != nil {
+		t.pin.Release()
+	}
+
+	if !t.temporary {
+		return
+	}
+
+	if err := util.DeleteFile(t.path); err != nil {
+		logger.Warn("could not delete file", zap.Error(err), zap.String("file_name", t.path))
+	}
+}
+
+// lookupCachedTrack returns the track from the audio cache, if there is one. The
+// cached file is kept until the track is released.
+func (m *PlayerCog) lookupCachedTrack(audioTrackQuery string) (*localTrack, bool) {
+	if m.audioCache == nil {
+		return nil, false
+	}
+
+	pin, ok := m.audioCache.Get(audioTrackQuery)
+	m.logger.Debug("audio cache lookup", zap.Bool("hit", ok), zap.String("query", audioTrackQuery))
+
+	if !ok {
+		return nil, false
+	}
+
+	return newCachedTrack(pin), true
+}
+
+// resolveTrackQuery falls back to the track's own query when it can't be resolved,
+// yt-dlp then simply repeats the search.
+func (m *PlayerCog) resolveTrackQuery(ctx context.Context, guildID string, track *audiotype.TrackData) string {
+	query, err := m.trackResolver.resolve(ctx, guildID, track)
+	if err != nil {
+		m.logger.Warn("unable to resolve track", zap.Error(err), logger.GuildID(guildID), zap.String("track_id", track.ID))
+		return track.Query
+	}
+
+	return query
+}
+
+// guildTrackPreparer returns a preparer for tracks as they are resolved in the guild.
+// Tracks are only downloaded ahead of time when downloads go through temporary files,
+// streamed downloads start along with the track so only the audio cache is checked.
+func (m *PlayerCog) guildTrackPreparer(guildID string) trackPreparer {
+	return func(ctx context.Context, track *audiotype.TrackData) (*preparedTrack, error) {
+		query := m.resolveTrackQuery(ctx, guildID, track)
+
+		if m.downloadMode == DownloadModeTempFile {
+			local, err := m.fetchTrack(ctx, query)
+			if err != nil {
+				return nil, err
+			}
+
+			return &preparedTrack{query: query, local: local}, nil
+		}
+
+		if local, ok := m.lookupCachedTrack(query); ok {
+			return &preparedTrack{query: query, local: local}, nil
+		}
+
+		return &preparedTrack{query: query}, nil
+	}
+}
+
+// fetchTrack makes the track available on disk, serving it from the audio cache
+// when possible and storing fresh downloads in it.
+func (m *PlayerCog) fetchTrack(ctx context.Context, audioTrackQuery string) (*localTrack, error) {
+	if track, ok := m.lookupCachedTrack(audioTrackQuery); ok {
+		return track, nil
+	}
+
+	file, err := m.downloadTrack(ctx, audioTrackQuery)
+	if err != nil {
+		return nil, fmt.Errorf("downloading result: %w", err)
+	}
+
+	if m.audioCache != nil {
+		pin, err := m.audioCache.Add(audioTrackQuery, file.Name())
+		if err == nil {
+			return newCachedTrack(pin), nil
+		}
+
+		m.logger.Warn("unable to add track to audio cache", zap.Error(err), zap.String("query", audioTrackQuery))
+	}
+
+	return &localTrack{path: file.Name(), temporary: true}, nil
+}
+
+// encodeTrack starts encoding the track, by default the yt-dlp download is piped
+// straight into the encoder so playback can begin with the first bytes received.
+// The returned cleanup function releases the download and the encoder.
+func (m *PlayerCog) encodeTrack(ctx context.Context, audioTrackQuery string, opts *dca.EncodeOptions) (*dca.EncodeSession, func(), error) {
+	if m.downloadMode == DownloadModeTempFile {
+		track, err := m.fetchTrack(ctx, audioTrackQuery)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return m.encodeLocalTrack(track, opts)
+	}
+
+	if track, ok := m.lookupCachedTrack(audioTrackQuery); ok {
+		return m.encodeLocalTrack(track, opts)
+	}
+
+	return m.encodeStream(ctx, audioTrackQuery, opts)
+}
+
+// encodeStream pipes the yt-dlp download into the encoder, storing it in the audio
+// cache once it was downloaded completely.
+func (m *PlayerCog) encodeStream(ctx context.Context, audioTrackQuery string, opts *dca.EncodeOptions) (*dca.EncodeSession, func(), error) {
+	downloadResult, err := m.openDownload(ctx, audioTrackQuery)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening download: %w", err)
+	}
+
+	closeDownload := func() {
+		if err := downloadResult.Close(); err != nil {
+			m.logger.Warn("couldn't close downloaded result", zap.Error(err))
+		}
+	}
+
+	var (
+		source      io.Reader = downloadResult
+		cacheWriter *audiocache.Writer
+		eofReader   *eofTrackingReader
+	)
+
+	if m.audioCache != nil {
+		if cacheWriter, err = m.audioCache.NewWriter(audioTrackQuery); err != nil {
+			m.logger.Warn("unable to cache streamed track", zap.Error(err))
+		} else {
+			eofReader = &eofTrackingReader{reader: io.TeeReader(downloadResult, cacheWriter)}
+			source = eofReader
+		}
+	}
+
+	encodingStream, err := dca.EncodeMem(source, opts)
+	if err != nil {
+		closeDownload()
+
+		if cacheWriter != nil {
+			cacheWriter.Abort()
+		}
+
+		return nil, nil, fmt.Errorf("encoding download stream: %w", err)
+	}
+
+	return encodingStream, func() {
+		// closing the download first unblocks the encoder if it is waiting on input
+		closeDownload()
+		encodingStream.Cleanup()
+
+		if cacheWriter == nil {
+			return
+		}
+
+		// only complete downloads are cached, tracks that were skipped are discarded
+		if !eofReader.reachedEOF() {
+			cacheWriter.Abort()
+			return
+		}
+
+		if err := cacheWriter.Commit(); err != nil {
+			m.logger.Warn("unable to add streamed track to audio cache", zap.Error(err), zap.String("query", audioTrackQuery))
+		}
+	}, nil
+}
+
+// encodeLocalTrack encodes a track stored on disk, the track is released by the
+// returned cleanup function.
+func (m *PlayerCog) encodeLocalTrack(track *localTrack, opts *dca.EncodeOptions) (*dca.EncodeSession, func(), error) {
+	encodingStream, err := dca.EncodeFile(track.path, opts)
+	if err != nil {
+		track.release(m.logger)
+		return nil, nil, fmt.Errorf("encoding file: %w", err)
+	}
+
+	return encodingStream, func() {
+		encodingStream.Cleanup()
+		track.release(m.logger)
+	}, nil
+}
+
+// encodeDirectFile has ffmpeg read the audio file from its url, skipping yt-dlp.
+func (m *PlayerCog) encodeDirectFile(fileURL string, opts *dca.EncodeOptions) (*dca.EncodeSession, func(), error) {
+	encodingStream, err := dca.EncodeFile(fileURL, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding direct file: %w", err)
+	}
+
+	return encodingStream, encodingStream.Cleanup, nil
+}
+
+// encodeRadioStream encodes a live stream, keeping the player updated with the
+// titles the station announces. HLS streams are handed to ffmpeg directly, which
+// means their titles are not shown.
+func (m *PlayerCog) encodeRadioStream(ctx context.Context, guildPlayer *guildPlayer, track *audiotype.TrackData, opts *dca.EncodeOptions) (*dca.EncodeSession, func(), error) {
+	guildPlayer.setStreamTitle(track, "")
+
+	stream, err := radio.Open(ctx, track.Query, func(title string) {
+		guildPlayer.setStreamTitle(track, title)
+
+		if guildPlayer.hasView() {
+			go func() {
+				if err := guildPlayer.refreshState(m.session); err != nil {
+					m.logger.Warn("unable to refresh views", zap.Error(err), logger.GuildID(guildPlayer.guildID))
+				}
+			}()
+		}
+	})
+	if err != nil {
+		if errors.Is(err, radio.ErrHLSStream) {
+			return m.encodeDirectFile(track.Query, opts)
+		}
+
+		return nil, nil, fmt.Errorf("opening radio stream: %w", err)
+	}
+
+	encodingStream, err := dca.EncodeMem(stream, opts)
+	if err != nil {
+		_ = stream.Close()
+		return nil, nil, fmt.Errorf("encoding radio stream: %w", err)
+	}
+
+	cleanup := func() {
+		_ = stream.Close()
+		encodingStream.Cleanup()
+	}
+
+	return encodingStream, cleanup, nil
+}
+
+// reconnectRadio retries a live stream that failed or dropped, backing off between
+// attempts, and moves on through the queue once the station stays unreachable.
+func (m *PlayerCog) reconnectRadio(guildPlayer *guildPlayer, streamErr error, streamedFor time.Duration) error {
+	attempt := guildPlayer.nextRadioReconnect(streamedFor)
+
+	m.logger.Warn("radio stream dropped", zap.Error(streamErr), zap.Int("attempt", attempt), logger.GuildID(guildPlayer.guildID))
+
+	if attempt > maxRadioReconnects {
+		guildPlayer.resetRadioReconnects()
+		m.advanceQueue(guildPlayer)
+
+		return nil
+	}
+
+	select {
+	case <-time.After(time.Duration(attempt) * radioReconnectDelay):
+	// the queue was moved while waiting to reconnect
+	case <-guildPlayer.stopChannel:
+		guildPlayer.resetRadioReconnects()
+	}
+
+	m.songSignal <- guildPlayer
+
+	return nil
+}
+
+// eofTrackingReader records whether the underlying reader was read to completion.
+type eofTrackingReader struct {
+	reader io.Reader
+	eof    atomic.Bool
+}
+
+func (r *eofTrackingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if errors.Is(err, io.EOF) {
+		r.eof.Store(true)
+	}
+
+	return n, err
+}
+
+func (r *eofTrackingReader) reachedEOF() bool {
+	return r.eof.Load()
+}
+
 func (m *PlayerCog) playAudio(guildPlayer *guildPlayer) error {
 	// exit if no voice client or no tracks in the queue
 	if guildPlayer == nil || guildPlayer.voiceClient == nil || guildPlayer.isQueueDepleted() {
@@ -110,48 +435,124 @@ func (m *PlayerCog) playAudio(guildPlayer *guildPlayer) error {
 		}
 	}
 
-	audioTrackQuery := guildPlayer.getCurrentSong().Query
+	currentTrack := guildPlayer.getCurrentSong()
 
-	ctx := context.Background()
+	// podcast episodes pick up where their requester left off, unless a position was asked for
+	startOffset, seeked := guildPlayer.takeStartOffset()
+	if !seeked && currentTrack.AudioType == audiotype.PodcastEpisode {
+		startOffset = m.podcastResumePosition(guildPlayer, currentTrack)
+		guildPlayer.resumeFrom(startOffset)
+	}
 
-	file, err := m.downloadTrack(ctx, audioTrackQuery)
-	if err != nil {
-		return fmt.Errorf("downloading result: %w", err)
+	// links to a moment in a video start there
+	if !seeked && currentTrack.StartAt > 0 {
+		startOffset = currentTrack.StartAt
+		guildPlayer.resumeFrom(startOffset)
 	}
 
-	defer func() {
-		if err := util.DeleteFile(file.Name()); err != nil {
-			m.logger.Warn("could not delete file", zap.Error(err), zap.String("file_name", file.Name()))
-		}
-	}()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	opts := dca.StdEncodeOptions
+	// copy the defaults, options are tailored to each guild player
+	opts := *dca.StdEncodeOptions
 	opts.RawOutput = true
 	opts.Bitrate = 120
+	// dca treats 256 as the unaltered volume
+	opts.Volume = guildPlayer.getVolume() * 256 / 100
+
+	// the start time is applied after filtering, so it needs to be
+	// expressed in the timeline of the filtered audio
+	filterPreset := audioFilterPresets[guildPlayer.getFilter()]
+	opts.AudioFilter = filterPreset.chain
+	opts.StartTime = int(startOffset.Seconds() / filterPreset.speed)
+
+	var (
+		encodingStream *dca.EncodeSession
+		cleanup        func()
+		err            error
+	)
+
+	switch currentTrack.AudioType {
+	case audiotype.DirectAudioFile, audiotype.PodcastEpisode, audiotype.LibraryTrack:
+		// audio files, podcast episodes and library tracks are read by ffmpeg itself
+		encodingStream, cleanup, err = m.encodeDirectFile(currentTrack.Query, &opts)
+	case audiotype.LiveRadio:
+		encodingStream, cleanup, err = m.encodeRadioStream(ctx, guildPlayer, currentTrack, &opts)
+	default:
+		// the track may already have been prepared while the previous one was playing
+		switch prepared := guildPlayer.takePrefetched(currentTrack); {
+		case prepared == nil:
+			encodingStream, cleanup, err = m.encodeTrack(ctx, m.resolveTrackQuery(ctx, guildPlayer.guildID, currentTrack), &opts)
+		case prepared.local != nil:
+			encodingStream, cleanup, err = m.encodeLocalTrack(prepared.local, &opts)
+		default:
+			// the audio cache was already checked while preparing the track
+			encodingStream, cleanup, err = m.encodeStream(ctx, prepared.query, &opts)
+		}
+	}
 
-	encodingStream, err := dca.EncodeFile(file.Name(), opts)
 	if err != nil {
-		return fmt.Errorf("encoding file: %w", err)
+		if currentTrack.AudioType == audiotype.LiveRadio {
+			return m.reconnectRadio(guildPlayer, err, 0)
+		}
+
+		return fmt.Errorf("encoding track: %w", err)
 	}
 
-	defer encodingStream.Cleanup()
+	defer cleanup()
 
 	guildPlayer.doneChannel = make(chan error)
+
+	// currentPosition reads both of these from command handlers
+	guildPlayer.mu.Lock()
+	guildPlayer.playbackSpeed = filterPreset.speed
 	guildPlayer.stream = dca.NewStream(encodingStream, guildPlayer.voiceClient, guildPlayer.doneChannel)
-	guildPlayer.setVoiceState(playing)
+	guildPlayer.mu.Unlock()
+
+	// changing the volume or filter while paused restarts the encode, which shouldn't resume playback
+	if guildPlayer.takeRestartPaused() {
+		guildPlayer.stream.SetPaused(true)
+		guildPlayer.setVoiceState(paused)
+	} else {
+		guildPlayer.setVoiceState(playing)
+	}
+	streamStart := time.Now()
+
+	// start downloading the upcoming track so the transition to it is gapless
+	guildPlayer.prefetchNext()
+
+	var podcastProgress <-chan time.Time
+	if currentTrack.AudioType == audiotype.PodcastEpisode {
+		ticker := time.NewTicker(podcastProgressInterval)
+		defer ticker.Stop()
+
+		podcastProgress = ticker.C
+	}
 
 	for {
 		select {
 		case err := <-guildPlayer.doneChannel:
+			// live streams never finish, reaching the end means the stream dropped
+			if currentTrack.AudioType == audiotype.LiveRadio {
+				return m.reconnectRadio(guildPlayer, err, time.Since(streamStart))
+			}
+
+			if currentTrack.AudioType == audiotype.PodcastEpisode {
+				position := guildPlayer.currentPosition()
+				if errors.Is(err, io.EOF) {
+					// finished episodes start over next time
+					position = 0
+				}
+
+				m.recordPodcastProgress(guildPlayer, currentTrack, position)
+			}
+
 			if err != nil {
 				if errors.Is(err, io.EOF) {
-					if guildPlayer.hasNext() {
-						guildPlayer.skip()
+					if guildPlayer.getLoopMode() == loopTrack {
 						m.songSignal <- guildPlayer
 					} else {
-						guildPlayer.setVoiceState(notPlaying)
-						guildPlayer.resetQueue()
-						guildPlayer.destroyAllViews(m.session)
+						m.advanceQueue(guildPlayer)
 					}
 				} else {
 					m.logger.Warn("error during audio stream", zap.Error(err))
@@ -160,8 +561,17 @@ func (m *PlayerCog) playAudio(guildPlayer *guildPlayer) error {
 
 			return nil
 
+		case <-podcastProgress:
+			m.recordPodcastProgress(guildPlayer, currentTrack, guildPlayer.currentPosition())
+
 		// receiving signal from stop channel indicates queue ptr has shifted
 		case <-guildPlayer.stopChannel:
+			// episodes that are restarted for a seek or setting change keep playing, so
+			// only leaving the episode is recorded
+			if currentTrack.AudioType == audiotype.PodcastEpisode && !guildPlayer.isRestarting() {
+				m.recordPodcastProgress(guildPlayer, currentTrack, guildPlayer.currentPosition())
+			}
+
 			m.songSignal <- guildPlayer
 
 			return nil
@@ -169,9 +579,33 @@ func (m *PlayerCog) playAudio(guildPlayer *guildPlayer) error {
 	}
 }
 
+// advanceQueue moves on to the next track, or stops playing once the queue has run out.
+func (m *PlayerCog) advanceQueue(guildPlayer *guildPlayer) {
+	if guildPlayer.canSkip() {
+		guildPlayer.skip()
+		m.songSignal <- guildPlayer
+
+		return
+	}
+
+	guildPlayer.setVoiceState(notPlaying)
+	guildPlayer.resetQueue()
+	guildPlayer.destroyAllViews(m.session)
+}
+
 func (m *PlayerCog) addToQueue(session *discordgo.Session, interaction *discordgo.InteractionCreate, trackData *audiotype.Data, guildPlayer *guildPlayer) error {
-	addedPosition := guildPlayer.remainingQueueLength() + 1
-	guildPlayer.addTracks(trackData.Tracks...)
+	return m.insertIntoQueue(session, interaction, trackData, guildPlayer, endOfQueue)
+}
+
+// insertIntoQueue adds the tracks at a position counted from the current track, see
+// guildPlayer.insertTracks, and lets the requester know where they ended up.
+func (m *PlayerCog) insertIntoQueue(session *discordgo.Session, interaction *discordgo.InteractionCreate, trackData *audiotype.Data, guildPlayer *guildPlayer, position int) error {
+	// tracks are matched to their requester by id, as usernames can change while queued
+	for _, track := range trackData.Tracks {
+		track.RequesterID = interaction.Member.User.ID
+	}
+
+	addedPosition := guildPlayer.insertTracks(position, trackData.Tracks...)
 
 	if guildPlayer.isNotActive() {
 		m.songSignal <- guildPlayer
@@ -244,6 +678,85 @@ func (m *PlayerCog) reportErrorToSupportChannel(session *discordgo.Session, inte
 	return nil
 }
 
+// playQuery returns what the play command should retrieve, attachments are
+// played as direct audio files.
+func playQuery(data discordgo.ApplicationCommandInteractionData) (string, audiotype.SupportedAudioType, error) {
+	for _, option := range data.Options {
+		switch option.Name {
+		case "query":
+			query := option.StringValue()
+
+			audioType, err := audiotype.DetermineAudioType(query)
+			if err != nil {
+				return "", "", fmt.Errorf("determining audio type: %w", err)
+			}
+
+			return query, audioType, nil
+
+		case "file":
+			if data.Resolved == nil {
+				return "", "", audiotype.ErrUnsupportedAudioType
+			}
+
+			attachmentID, _ := option.Value.(string)
+
+			attachment, ok := data.Resolved.Attachments[attachmentID]
+			if !ok || !strings.HasPrefix(attachment.ContentType, "audio/") {
+				return "", "", audiotype.ErrUnsupportedAudioType
+			}
+
+			return attachment.URL, audiotype.DirectAudioFile, nil
+		}
+	}
+
+	return "", "", audiotype.ErrUnsupportedAudioType
+}
+
+// parsePositionRange parses a queue position or an inclusive range of them, such as "5-9".
+func parsePositionRange(value string) (int, int, error) {
+	firstValue, lastValue, isRange := strings.Cut(value, "-")
+
+	first, err := strconv.Atoi(strings.TrimSpace(firstValue))
+	if err != nil {
+		return 0, 0, errInvalidPosition
+	}
+
+	last := first
+	if isRange {
+		if last, err = strconv.Atoi(strings.TrimSpace(lastValue)); err != nil {
+			return 0, 0, errInvalidPosition
+		}
+	}
+
+	if first < 1 || last < first {
+		return 0, 0, errInvalidPosition
+	}
+
+	return first, last, nil
+}
+
+// retrievalTimeout returns how long retrieving tracks may take, sources extracted
+// through yt-dlp need considerably longer than the ones backed by an API.
+func (m *PlayerCog) retrievalTimeout(audioType audiotype.SupportedAudioType) time.Duration {
+	// YouTube may be served by the Data API or yt-dlp, which only the wrapper knows
+	if audiotype.IsYoutube(audioType) || audioType == audiotype.GenericSearch {
+		return m.ytSearchWrapper.RetrievalTimeout(audioType)
+	}
+
+	if audiotype.IsSoundCloud(audioType) || audioType == audiotype.GenericURL || audioType == audiotype.DirectAudioFile {
+		return time.Minute
+	}
+
+	// these are read from public pages or paged through, taking a few requests
+	if audiotype.IsAppleMusic(audioType) || audiotype.IsDeezer(audioType) || audiotype.IsTidal(audioType) {
+		return time.Second * 15
+	}
+
+	return time.Second * 5
+}
+
+var errNoLibrary = errors.New("no music library is configured")
+
 func (m *PlayerCog) retrieveTracks(ctx context.Context, audioType audiotype.SupportedAudioType, query string) (*audiotype.Data, error) {
 	if audiotype.IsSpotify(audioType) {
 		return m.spotifyClient.GetTracksData(ctx, audioType, query)
@@ -253,6 +766,38 @@ func (m *PlayerCog) retrieveTracks(ctx context.Context, audioType audiotype.Supp
 		return m.ytSearchWrapper.GetTracksData(ctx, audioType, query)
 	}
 
+	if audiotype.IsSoundCloud(audioType) {
+		return m.soundCloudWrapper.GetTracksData(ctx, audioType, query)
+	}
+
+	if audiotype.IsAppleMusic(audioType) {
+		return m.appleMusicWrapper.GetTracksData(ctx, audioType, query)
+	}
+
+	if audiotype.IsDeezer(audioType) {
+		return m.deezerWrapper.GetTracksData(ctx, audioType, query)
+	}
+
+	if audiotype.IsTidal(audioType) {
+		return m.tidalWrapper.GetTracksData(ctx, audioType, query)
+	}
+
+	if audioType == audiotype.GenericURL {
+		return m.genericWrapper.GetTracksData(ctx, audioType, query)
+	}
+
+	if audioType == audiotype.DirectAudioFile {
+		return m.directFileWrapper.GetTracksData(ctx, audioType, query)
+	}
+
+	if audioType == audiotype.LibraryTrack {
+		if m.libraryWrapper == nil {
+			return nil, errNoLibrary
+		}
+
+		return m.libraryWrapper.GetTracksData(ctx, audioType, query)
+	}
+
 	return nil, audiotype.ErrUnsupportedAudioType
 }
 
@@ -269,14 +814,19 @@ func (m *PlayerCog) RegisterCommands(session *discordgo.Session) {
 		panic(fmt.Errorf("failed to fetch existing commands: %w", err))
 	}
 
-	existingCommandNames := make(map[string]struct{})
+	existingCommandIDs := make(map[string]string)
 	for _, cmd := range existingCommands {
-		existingCommandNames[cmd.Name] = struct{}{}
+		existingCommandIDs[cmd.Name] = cmd.ID
 	}
 
+	// commands registered by other cogs are left alone, so existing ones are edited rather than bulk overwritten
 	for _, command := range commandsToRegister {
-		if _, exists := existingCommandNames[command.Name]; exists {
-			m.logger.Info("Skipping registering command, since it already exists", zap.String("command_name", command.Name))
+		if commandID, exists := existingCommandIDs[command.Name]; exists {
+			m.logger.Info("Updating command, since it already exists", zap.String("command_name", command.Name))
+
+			if _, err := session.ApplicationCommandEdit(session.State.Application.ID, "", commandID, command); err != nil {
+				panic(fmt.Errorf("updating command %s: %w", command.Name, err))
+			}
 
 			continue
 		}
@@ -296,21 +846,60 @@ func (m *PlayerCog) RegisterCommands(session *discordgo.Session) {
 	session.AddHandler(m.guildDeleteEvent)
 }
 
+// playCommandOptions returns the options shared by the commands that queue a query or file.
+func playCommandOptions() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Name:         "query",
+			Description:  "Song/playlist search query, or library: followed by a search of the music library",
+			Type:         discordgo.ApplicationCommandOptionString,
+			Required:     false,
+			Autocomplete: true,
+		},
+		{
+			Name:        "file",
+			Description: "An audio file to play instead of a search query",
+			Type:        discordgo.ApplicationCommandOptionAttachment,
+			Required:    false,
+		},
+	}
+}
+
 func (m *PlayerCog) getApplicationCommands() map[string]*commands.ApplicationCommand {
+	minVolume := float64(0)
+	minPosition := float64(1)
+
 	return map[string]*commands.ApplicationCommand{
 		"play": {
 			Handler: m.play,
 			CommandConfiguration: &discordgo.ApplicationCommand{
 				Name:        "play",
 				Description: "Plays desired song/playlist",
-				Options: []*discordgo.ApplicationCommandOption{
+				Options:     playCommandOptions(),
+			},
+		},
+		"playnext": {
+			Handler: m.playNext,
+			CommandConfiguration: &discordgo.ApplicationCommand{
+				Name:        "playnext",
+				Description: "Queues desired song/playlist right after the current track",
+				Options:     playCommandOptions(),
+			},
+		},
+		"insert": {
+			Handler: m.insert,
+			CommandConfiguration: &discordgo.ApplicationCommand{
+				Name:        "insert",
+				Description: "Queues desired song/playlist at a chosen position in the queue",
+				Options: append([]*discordgo.ApplicationCommandOption{
 					{
-						Name:        "query",
-						Description: "Song/playlist search query",
-						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "position",
+						Description: "The position in the queue to insert at, 1 being right after the current track",
+						Type:        discordgo.ApplicationCommandOptionInteger,
 						Required:    true,
+						MinValue:    &minPosition,
 					},
-				},
+				}, playCommandOptions()...),
 			},
 		},
 		"help": {
@@ -356,6 +945,22 @@ func (m *PlayerCog) getApplicationCommands() map[string]*commands.ApplicationCom
 				Description: "Skips the current track playing",
 			},
 		},
+		"skipto": {
+			Handler: m.skipTo,
+			CommandConfiguration: &discordgo.ApplicationCommand{
+				Name:        "skipto",
+				Description: "Skips ahead to a track in the queue, the skipped tracks can still be rewound to",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "position",
+						Description: "The position of the track in the queue",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Required:    true,
+						MinValue:    &minPosition,
+					},
+				},
+			},
+		},
 		"rewind": {
 			Handler: m.rewind,
 			CommandConfiguration: &discordgo.ApplicationCommand{
@@ -363,6 +968,80 @@ func (m *PlayerCog) getApplicationCommands() map[string]*commands.ApplicationCom
 				Description: "Rewinds to the previous track in the queue",
 			},
 		},
+		"seek": {
+			Handler: m.seek,
+			CommandConfiguration: &discordgo.ApplicationCommand{
+				Name:        "seek",
+				Description: "Jumps to a position in the current track",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "timestamp",
+						Description: "The position to jump to (e.g. 1:30 or 1:02:03)",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+				},
+			},
+		},
+		"loop": {
+			Handler: m.loop,
+			CommandConfiguration: &discordgo.ApplicationCommand{
+				Name:        "loop",
+				Description: "Sets the loop mode of the player, cycles through modes if none is provided",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "mode",
+						Description: "The loop mode to use",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Off", Value: string(loopOff)},
+							{Name: "Track", Value: string(loopTrack)},
+							{Name: "Queue", Value: string(loopQueue)},
+						},
+					},
+				},
+			},
+		},
+		"volume": {
+			Handler: m.volume,
+			CommandConfiguration: &discordgo.ApplicationCommand{
+				Name:        "volume",
+				Description: "Sets the playback volume for this server",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "level",
+						Description: "The volume as a percentage, 100 being the original loudness",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Required:    true,
+						MinValue:    &minVolume,
+						MaxValue:    float64(maxVolume),
+					},
+				},
+			},
+		},
+		"filter": {
+			Handler: m.filter,
+			CommandConfiguration: &discordgo.ApplicationCommand{
+				Name:        "filter",
+				Description: "Applies an audio filter to the music for the rest of the session",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "preset",
+						Description: "The filter to apply, choose clear to remove the active filter",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+						Choices: funcs.Map(audioFilterOrder, func(filter audioFilter) *discordgo.ApplicationCommandOptionChoice {
+							if filter == noFilter {
+								return &discordgo.ApplicationCommandOptionChoice{Name: "Clear", Value: string(filter)}
+							}
+
+							return &discordgo.ApplicationCommandOptionChoice{Name: string(filter), Value: string(filter)}
+						}),
+					},
+				},
+			},
+		},
 		"swap": {
 			Handler: m.swap,
 			CommandConfiguration: &discordgo.ApplicationCommand{
@@ -384,17 +1063,179 @@ func (m *PlayerCog) getApplicationCommands() map[string]*commands.ApplicationCom
 				},
 			},
 		},
+		"move": {
+			Handler: m.move,
+			CommandConfiguration: &discordgo.ApplicationCommand{
+				Name:        "move",
+				Description: "Move a track, or a range of tracks, to a new position in the queue",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "from",
+						Description: "The position of the track to move, or a range of positions such as 5-9",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+					{
+						Name:        "to",
+						Description: "The position the track, or the first track of the range, should end up at",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Required:    true,
+						MinValue:    &minPosition,
+					},
+				},
+			},
+		},
+		"fix-track": {
+			Handler: m.fixTrack,
+			CommandConfiguration: &discordgo.ApplicationCommand{
+				Name:        "fix-track",
+				Description: "Corrects the YouTube video a Spotify track plays for everyone in this server",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "url",
+						Description: "The YouTube video the track should play",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+					{
+						Name:        "track_position",
+						Description: "The position of the track in the queue, defaults to the current track",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Required:    false,
+					},
+				},
+			},
+		},
+		"podcast": {
+			Handler: m.podcast,
+			CommandConfiguration: &discordgo.ApplicationCommand{
+				Name:        "podcast",
+				Description: "Browse the episodes of a podcast and pick the ones to queue",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "feed_url",
+						Description: "The link to the podcast's RSS or Atom feed",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+				},
+			},
+		},
+		"search": {
+			Handler: m.search,
+			CommandConfiguration: &discordgo.ApplicationCommand{
+				Name:        "search",
+				Description: "Search for a track and pick which of the top results to queue",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "query",
+						Description: "What to search for",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+					{
+						Name:        "source",
+						Description: "Where to search, defaults to YouTube",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "YouTube", Value: string(searchSourceYoutube)},
+							{Name: "Spotify", Value: string(searchSourceSpotify)},
+						},
+					},
+				},
+			},
+		},
+		"radio": {
+			Handler: m.radio,
+			CommandConfiguration: &discordgo.ApplicationCommand{
+				Name:        "radio",
+				Description: "Plays a live radio station, either a saved preset or a stream link",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "station",
+						Description:  "The name of a saved radio preset or a link to a stream",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+		},
+		"radio-save": {
+			Handler: m.radioSave,
+			CommandConfiguration: &discordgo.ApplicationCommand{
+				Name:        "radio-save",
+				Description: "Saves a radio station as a preset for this server",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "name",
+						Description: "The name the station will be saved under",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+						MaxLength:   50,
+					},
+					{
+						Name:        "url",
+						Description: "The link to the stream",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+				},
+			},
+		},
+		"radio-delete": {
+			Handler: m.radioDelete,
+			CommandConfiguration: &discordgo.ApplicationCommand{
+				Name:        "radio-delete",
+				Description: "Deletes one of this server's radio presets",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "preset_name",
+						Description:  "The name of the preset you want to delete",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+		},
 		"remove": {
 			Handler: m.remove,
 			CommandConfiguration: &discordgo.ApplicationCommand{
 				Name:        "remove",
-				Description: "Removes a specific track from the music queue by its position.",
+				Description: "Removes tracks from the music queue by position, range, requester, name or duplicates.",
 				Options: []*discordgo.ApplicationCommandOption{
 					{
 						Name:        "track_position",
 						Description: "The position of the track in the queue to remove.",
 						Type:        discordgo.ApplicationCommandOptionInteger,
-						Required:    true,
+						Required:    false,
+					},
+					{
+						Name:        "range",
+						Description: "A range of positions to remove, such as 5-9.",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    false,
+					},
+					{
+						Name:        "member",
+						Description: "Removes every upcoming track this member requested.",
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Required:    false,
+					},
+					{
+						Name:        "match",
+						Description: "Removes every upcoming track whose name contains this text.",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    false,
+						MaxLength:   100,
+					},
+					{
+						Name:        "duplicates",
+						Description: "Removes tracks that are already earlier in the queue.",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Required:    false,
 					},
 				},
 			},
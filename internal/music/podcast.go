@@ -0,0 +1,304 @@
+package music
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/TeddyKahwaji/spice-tunes-go/internal/embeds"
+	"github.com/TeddyKahwaji/spice-tunes-go/internal/logger"
+	"github.com/TeddyKahwaji/spice-tunes-go/internal/util"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/pagination"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/podcast"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/views"
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	podcastEpisodeSelectID string = "PodcastEpisodeSelect"
+	// select menus are limited to 25 options, pages are kept shorter so the embed stays readable
+	podcastPageSize int = 10
+	// positions this close to either end of an episode are not worth resuming from
+	podcastResumeMargin time.Duration = 30 * time.Second
+	// how often the position of a playing episode is saved, so it survives disconnects
+	podcastProgressInterval time.Duration = 30 * time.Second
+)
+
+// podcastEpisodeID derives a firestore safe key from the episode's guid.
+func podcastEpisodeID(episode *podcast.Episode) string {
+	sum := sha1.Sum([]byte(episode.GUID))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func newPodcastTrack(feed *podcast.Feed, episode *podcast.Episode, member *discordgo.Member) *audiotype.TrackData {
+	return &audiotype.TrackData{
+		TrackName:     fmt.Sprintf("%s - %s", episode.Title, feed.Title),
+		TrackImageURL: episode.ImageURL,
+		Query:         episode.AudioURL,
+		Requester:     member.User.Username,
+		RequesterID:   member.User.ID,
+		Duration:      episode.Duration,
+		ID:            podcastEpisodeID(episode),
+		AudioType:     audiotype.PodcastEpisode,
+	}
+}
+
+// getPodcastPosition returns where the user left off in the episode, or zero if
+// they haven't listened to it before.
+func (g *guildPlayer) getPodcastPosition(ctx context.Context, userID string, episodeID string) (time.Duration, error) {
+	doc, err := g.fireStoreClient.GetDocumentFromCollection(ctx, guildCollection, g.guildID).
+		Collection(userDataCollection).
+		Doc(userID).
+		Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("getting document: %w", err)
+	}
+
+	var userData userData
+	if err := doc.DataTo(&userData); err != nil {
+		return 0, fmt.Errorf("converting data to userData struct: %w", err)
+	}
+
+	return time.Duration(userData.PodcastPositions[episodeID]) * time.Second, nil
+}
+
+// savePodcastPosition remembers where the requester of the episode left off, positions
+// near either end of the episode are forgotten so it starts over next time.
+func (g *guildPlayer) savePodcastPosition(ctx context.Context, track *audiotype.TrackData, position time.Duration) error {
+	docRef := g.fireStoreClient.GetDocumentFromCollection(ctx, guildCollection, g.guildID).
+		Collection(userDataCollection).
+		Doc(track.RequesterID)
+
+	if position < podcastResumeMargin || (track.Duration > 0 && position > track.Duration-podcastResumeMargin) {
+		if _, err := docRef.Update(ctx, []firestore.Update{
+			{FieldPath: firestore.FieldPath{podcastPositionsPath, track.ID}, Value: firestore.Delete},
+		}); err != nil && status.Code(err) != codes.NotFound {
+			return fmt.Errorf("clearing podcast position: %w", err)
+		}
+
+		return nil
+	}
+
+	if _, err := docRef.Set(ctx, map[string]interface{}{
+		podcastPositionsPath: map[string]interface{}{track.ID: int64(position.Seconds())},
+	}, firestore.MergeAll); err != nil {
+		return fmt.Errorf("saving podcast position: %w", err)
+	}
+
+	return nil
+}
+
+// podcastResumePosition returns the position the episode should start from, failing
+// to look it up simply starts the episode from the beginning.
+func (m *PlayerCog) podcastResumePosition(guildPlayer *guildPlayer, track *audiotype.TrackData) time.Duration {
+	if track.RequesterID == "" {
+		return 0
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	position, err := guildPlayer.getPodcastPosition(ctx, track.RequesterID, track.ID)
+	if err != nil {
+		m.logger.Warn("unable to retrieve podcast position", zap.Error(err), logger.GuildID(guildPlayer.guildID), logger.UserID(track.RequesterID))
+		return 0
+	}
+
+	return position
+}
+
+// recordPodcastProgress saves the position in the background so playback isn't held up.
+func (m *PlayerCog) recordPodcastProgress(guildPlayer *guildPlayer, track *audiotype.TrackData, position time.Duration) {
+	if track.RequesterID == "" {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+
+		if err := guildPlayer.savePodcastPosition(ctx, track, position); err != nil {
+			m.logger.Warn("unable to save podcast position", zap.Error(err), logger.GuildID(guildPlayer.guildID), logger.UserID(track.RequesterID))
+		}
+	}()
+}
+
+func podcastEpisodeSelectMenu(episodes []*podcast.Episode, pageNum int, separator int) []discordgo.MessageComponent {
+	firstIndex := (pageNum - 1) * separator
+	minValues := 1
+
+	options := make([]discordgo.SelectMenuOption, 0, len(episodes))
+	for i, episode := range episodes {
+		label := fmt.Sprintf("%d: %s", firstIndex+i+1, episode.Title)
+		if len([]rune(label)) > 100 {
+			label = string([]rune(label)[:97]) + "..."
+		}
+
+		options = append(options, discordgo.SelectMenuOption{
+			Label: label,
+			Value: strconv.Itoa(firstIndex + i),
+		})
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    podcastEpisodeSelectID,
+					Placeholder: "Choose episodes to queue",
+					MinValues:   &minValues,
+					MaxValues:   len(options),
+					Options:     options,
+				},
+			},
+		},
+	}
+}
+
+func (m *PlayerCog) podcast(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
+	isInVoiceChannel, err := m.verifyInChannelAndSendError(session, interaction)
+	if err != nil {
+		return fmt.Errorf("verifying in voice channel: %w", err)
+	}
+
+	if !isInVoiceChannel {
+		return nil
+	}
+
+	if err := session.InteractionRespond(interaction.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		return fmt.Errorf("deferring message: %w", err)
+	}
+
+	feedURL := interaction.ApplicationCommandData().Options[0].StringValue()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	feed, err := podcast.Fetch(ctx, m.httpClient, feedURL)
+	if err != nil {
+		m.logger.Warn("unable to fetch podcast feed", zap.Error(err), logger.GuildID(interaction.GuildID))
+
+		message := "Could not read that podcast feed, please make sure the link points at an RSS or Atom feed"
+		if errors.Is(err, podcast.ErrNoEpisodes) {
+			message = "That podcast feed does not have any audio episodes"
+		}
+
+		msgData := util.MessageData{
+			Embeds: embeds.ErrorMessageEmbed(message),
+			Type:   discordgo.InteractionResponseChannelMessageWithSource,
+		}
+
+		if err := util.SendMessage(session, interaction.Interaction, true, msgData, util.WithDeletion(10*time.Second, interaction.ChannelID)); err != nil {
+			return fmt.Errorf("sending follow up message: %w", err)
+		}
+
+		return nil
+	}
+
+	paginationConfig := pagination.NewPaginatedConfig(feed.Episodes, podcastPageSize)
+	paginationConfig.SetPageComponents(podcastEpisodeSelectMenu)
+
+	getEpisodesEmbed := func(episodes []*podcast.Episode, pageNumber int, totalPages int, separator int) *discordgo.MessageEmbed {
+		return embeds.PodcastEpisodesEmbed(feed, episodes, pageNumber, totalPages, separator)
+	}
+
+	handler := func(passedInteraction *discordgo.Interaction) error {
+		if passedInteraction.MessageComponentData().CustomID == podcastEpisodeSelectID {
+			return m.queuePodcastEpisodes(session, passedInteraction, feed)
+		}
+
+		viewConfig := paginationConfig.GetViewConfig(getEpisodesEmbed)
+		if err := session.InteractionRespond(passedInteraction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Components: viewConfig.Components.MessageComponents,
+				Embeds:     viewConfig.Embeds,
+			},
+		}); err != nil {
+			return fmt.Errorf("sending update message: %w", err)
+		}
+
+		return nil
+	}
+
+	handler = paginationConfig.GetBaseHandler(session, handler)
+	episodesView := views.NewView(paginationConfig.GetViewConfig(getEpisodesEmbed), views.WithLogger(m.logger), views.WithDeletion(10*time.Minute))
+
+	if err := episodesView.SendView(interaction.Interaction, session, handler); err != nil {
+		return fmt.Errorf("sending podcast episodes view: %w", err)
+	}
+
+	return nil
+}
+
+// queuePodcastEpisodes queues the episodes picked from the select menu on behalf of whoever picked them.
+func (m *PlayerCog) queuePodcastEpisodes(session *discordgo.Session, interaction *discordgo.Interaction, feed *podcast.Feed) error {
+	interactionCreate := &discordgo.InteractionCreate{Interaction: interaction}
+
+	isInVoiceChannel, err := m.verifyInChannelAndSendError(session, interactionCreate)
+	if err != nil {
+		return fmt.Errorf("verifying in voice channel: %w", err)
+	}
+
+	if !isInVoiceChannel {
+		return nil
+	}
+
+	if err := m.joinAndCreateGuildPlayer(session, interactionCreate); err != nil {
+		return fmt.Errorf("joining and creating guild player: %w", err)
+	}
+
+	if err := session.InteractionRespond(interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	}); err != nil {
+		return fmt.Errorf("deferring message update: %w", err)
+	}
+
+	selected := interaction.MessageComponentData().Values
+	tracks := make([]*audiotype.TrackData, 0, len(selected))
+
+	for _, value := range selected {
+		index, err := strconv.Atoi(value)
+		if err != nil || index < 0 || index >= len(feed.Episodes) {
+			continue
+		}
+
+		tracks = append(tracks, newPodcastTrack(feed, feed.Episodes[index], interaction.Member))
+	}
+
+	if len(tracks) == 0 {
+		return nil
+	}
+
+	trackData := &audiotype.Data{
+		Tracks: tracks,
+		Type:   audiotype.PodcastEpisode,
+		PlaylistData: &audiotype.PlaylistData{
+			PlaylistName:     feed.Title,
+			PlaylistImageURL: feed.ImageURL,
+		},
+		ID: tracks[0].ID,
+	}
+
+	if err := m.addToQueue(session, interactionCreate, trackData, m.guildVoiceStates[interaction.GuildID]); err != nil {
+		return fmt.Errorf("adding to queue: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package music
+
+import (
+	"context"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+	"go.uber.org/zap"
+)
+
+// preparedTrack is a track ready to be encoded, query is what yt-dlp is given for it
+// and local is set when the track is already on disk.
+type preparedTrack struct {
+	query string
+	local *localTrack
+}
+
+type trackPreparer func(ctx context.Context, track *audiotype.TrackData) (*preparedTrack, error)
+
+// prefetchedTrack is the upcoming track of a guild player being prepared in the
+// background, so the transition to it doesn't wait on resolving it.
+type prefetchedTrack struct {
+	track    *audiotype.TrackData
+	cancel   context.CancelFunc
+	done     chan struct{}
+	prepared *preparedTrack
+	err      error
+}
+
+// discard cancels the preparation and releases the file it made available once
+// it has wound down.
+func (p *prefetchedTrack) discard(logger *zap.Logger) {
+	p.cancel()
+
+	go func() {
+		<-p.done
+
+		if p.prepared != nil && p.prepared.local != nil {
+			p.prepared.local.release(logger)
+		}
+	}()
+}
+
+// peekNextTrack returns the track that will play once the current one finishes,
+// taking the loop mode into account.
+func (g *guildPlayer) peekNextTrack() *audiotype.TrackData {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	currentPtr := g.getCurrentPointer()
+	if currentPtr >= len(g.queue) {
+		return nil
+	}
+
+	switch {
+	case g.loopMode == loopTrack:
+		return g.queue[currentPtr]
+	case currentPtr+1 < len(g.queue):
+		return g.queue[currentPtr+1]
+	case g.loopMode == loopQueue:
+		return g.queue[0]
+	}
+
+	return nil
+}
+
+// prefetchNext starts preparing the upcoming track, any prefetch made for a
+// track that is no longer up next is discarded.
+func (g *guildPlayer) prefetchNext() {
+	nextTrack := g.peekNextTrack()
+	// tracks with an audio type are played without yt-dlp, there is nothing to prefetch
+	if g.isNotActive() || (nextTrack != nil && nextTrack.AudioType != "") {
+		nextTrack = nil
+	}
+
+	g.prefetchMu.Lock()
+	defer g.prefetchMu.Unlock()
+
+	if g.prefetch != nil {
+		if g.prefetch.track == nextTrack {
+			return
+		}
+
+		g.prefetch.discard(g.logger)
+		g.prefetch = nil
+	}
+
+	if nextTrack == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	prefetch := &prefetchedTrack{
+		track:  nextTrack,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	g.prefetch = prefetch
+
+	go func() {
+		defer close(prefetch.done)
+
+		prefetch.prepared, prefetch.err = g.preparer(ctx, nextTrack)
+	}()
+}
+
+// invalidatePrefetch re-evaluates the prefetched track after the queue has changed.
+// It runs in the background so it is safe to call while holding the player lock.
+func (g *guildPlayer) invalidatePrefetch() {
+	go g.prefetchNext()
+}
+
+// discardPrefetch drops the prefetched track without prefetching another one.
+func (g *guildPlayer) discardPrefetch() {
+	g.prefetchMu.Lock()
+	defer g.prefetchMu.Unlock()
+
+	if g.prefetch != nil {
+		g.prefetch.discard(g.logger)
+		g.prefetch = nil
+	}
+}
+
+// takePrefetched hands over the prepared track. Nil is returned when the track wasn't
+// prefetched, the prefetch failed or it is still in progress, in which case it is
+// cancelled rather than holding up playback.
+func (g *guildPlayer) takePrefetched(track *audiotype.TrackData) *preparedTrack {
+	g.prefetchMu.Lock()
+	prefetch := g.prefetch
+	if prefetch == nil || prefetch.track != track {
+		g.prefetchMu.Unlock()
+		return nil
+	}
+
+	g.prefetch = nil
+	g.prefetchMu.Unlock()
+
+	select {
+	case <-prefetch.done:
+	default:
+		prefetch.discard(g.logger)
+		return nil
+	}
+
+	prefetch.cancel()
+
+	if prefetch.err != nil {
+		g.logger.Warn("prefetching track failed", zap.Error(prefetch.err), zap.String("query", track.Query))
+		return nil
+	}
+
+	return prefetch.prepared
+}
@@ -0,0 +1,124 @@
+package music
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errRadioPresetDoesNotExist = errors.New("the provided radio preset does not exist")
+	errTooManyRadioPresets     = errors.New("guild has reached the radio preset limit")
+)
+
+const (
+	radioPresetsField string = "RadioPresets"
+	// autocomplete can only suggest 25 choices
+	maxRadioPresets int = 25
+)
+
+// radioPreset is a radio station saved by a guild under a name of its choosing.
+type radioPreset struct {
+	Name string
+	URL  string
+}
+
+type guildRadioPresets struct {
+	RadioPresets map[string]string `firestore:"RadioPresets"`
+}
+
+func getRadioPresets(ctx context.Context, fs FireStore, guildID string) ([]radioPreset, error) {
+	doc, err := fs.GetDocumentFromCollection(ctx, guildCollection, guildID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return []radioPreset{}, nil
+		}
+
+		return nil, fmt.Errorf("getting guild document: %w", err)
+	}
+
+	var data guildRadioPresets
+	if err := doc.DataTo(&data); err != nil {
+		return nil, fmt.Errorf("converting data to guildRadioPresets struct: %w", err)
+	}
+
+	presets := make([]radioPreset, 0, len(data.RadioPresets))
+	for name, url := range data.RadioPresets {
+		presets = append(presets, radioPreset{Name: name, URL: url})
+	}
+
+	slices.SortFunc(presets, func(a, b radioPreset) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+
+	return presets, nil
+}
+
+// findRadioPreset looks up a preset by name, ignoring case.
+func findRadioPreset(presets []radioPreset, name string) (radioPreset, bool) {
+	for _, preset := range presets {
+		if strings.EqualFold(preset.Name, name) {
+			return preset, true
+		}
+	}
+
+	return radioPreset{}, false
+}
+
+// saveRadioPreset stores the station under the name, replacing any preset already using it.
+func saveRadioPreset(ctx context.Context, fs FireStore, guildID string, name string, url string) error {
+	presets, err := getRadioPresets(ctx, fs, guildID)
+	if err != nil {
+		return err
+	}
+
+	docRef := fs.GetDocumentFromCollection(ctx, guildCollection, guildID)
+
+	existing, ok := findRadioPreset(presets, name)
+	if !ok && len(presets) >= maxRadioPresets {
+		return errTooManyRadioPresets
+	}
+
+	// a preset saved with different casing is renamed rather than duplicated
+	if ok && existing.Name != name {
+		if _, err := docRef.Update(ctx, []firestore.Update{
+			{FieldPath: firestore.FieldPath{radioPresetsField, existing.Name}, Value: firestore.Delete},
+		}); err != nil {
+			return fmt.Errorf("removing previous radio preset: %w", err)
+		}
+	}
+
+	if _, err := docRef.Set(ctx, map[string]interface{}{
+		radioPresetsField: map[string]interface{}{name: url},
+	}, firestore.MergeAll); err != nil {
+		return fmt.Errorf("saving radio preset: %w", err)
+	}
+
+	return nil
+}
+
+func deleteRadioPreset(ctx context.Context, fs FireStore, guildID string, name string) error {
+	presets, err := getRadioPresets(ctx, fs, guildID)
+	if err != nil {
+		return err
+	}
+
+	preset, ok := findRadioPreset(presets, name)
+	if !ok {
+		return errRadioPresetDoesNotExist
+	}
+
+	if _, err := fs.GetDocumentFromCollection(ctx, guildCollection, guildID).Update(ctx, []firestore.Update{
+		{FieldPath: firestore.FieldPath{radioPresetsField, preset.Name}, Value: firestore.Delete},
+	}); err != nil {
+		return fmt.Errorf("deleting radio preset: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,261 @@
+package music
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/internal/embeds"
+	"github.com/TeddyKahwaji/spice-tunes-go/internal/logger"
+	"github.com/TeddyKahwaji/spice-tunes-go/internal/util"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/views"
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+type searchSource string
+
+const (
+	searchSourceYoutube searchSource = "youtube"
+	searchSourceSpotify searchSource = "spotify"
+)
+
+const (
+	searchResultSelectID string = "SearchResultSelect"
+	// select menus are limited to 25 options, fewer results keep the embed readable
+	searchResultLimit int = 10
+	// searches may fall back to yt-dlp, which is a lot slower than the apis
+	searchTimeout time.Duration = 15 * time.Second
+	// how long the results can be picked from before the view is removed
+	searchViewTimeout time.Duration = 2 * time.Minute
+)
+
+func searchResultSelectMenu(tracks []*audiotype.TrackData) []discordgo.MessageComponent {
+	options := make([]discordgo.SelectMenuOption, 0, len(tracks))
+	for i, track := range tracks {
+		label := fmt.Sprintf("%d: %s", i+1, track.TrackName)
+		if len([]rune(label)) > 100 {
+			label = string([]rune(label)[:97]) + "..."
+		}
+
+		option := discordgo.SelectMenuOption{
+			Label: label,
+			Value: strconv.Itoa(i),
+		}
+
+		if track.Duration > 0 {
+			option.Description = audiotype.FormatDuration(track.Duration)
+		}
+
+		options = append(options, option)
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    searchResultSelectID,
+					Placeholder: "Choose a track to queue",
+					Options:     options,
+				},
+			},
+		},
+	}
+}
+
+// searchTracks returns the top results of the query from the given source, along with
+// the audio type the chosen track is queued as.
+func (m *PlayerCog) searchTracks(ctx context.Context, source searchSource, query string) ([]*audiotype.TrackData, audiotype.SupportedAudioType, error) {
+	if source == searchSourceSpotify {
+		tracks, err := m.spotifyClient.SearchTracks(ctx, query, searchResultLimit)
+
+		return tracks, audiotype.SpotifyTrack, err
+	}
+
+	tracks, err := m.ytSearchWrapper.SearchTracks(ctx, query, searchResultLimit)
+
+	return tracks, audiotype.YoutubeSong, err
+}
+
+func (m *PlayerCog) search(session *discordgo.Session, interaction *discordgo.InteractionCreate) error {
+	isInVoiceChannel, err := m.verifyInChannelAndSendError(session, interaction)
+	if err != nil {
+		return fmt.Errorf("verifying in voice channel: %w", err)
+	}
+
+	if !isInVoiceChannel {
+		return nil
+	}
+
+	if err := session.InteractionRespond(interaction.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		return fmt.Errorf("deferring message: %w", err)
+	}
+
+	var query string
+
+	source := searchSourceYoutube
+
+	for _, option := range interaction.ApplicationCommandData().Options {
+		switch option.Name {
+		case "query":
+			query = option.StringValue()
+		case "source":
+			source = searchSource(option.StringValue())
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), searchTimeout)
+	defer cancel()
+	ctx = context.WithValue(ctx, audiotype.ContextKey("requesterName"), interaction.Member.User.Username)
+
+	tracks, audioType, err := m.searchTracks(ctx, source, query)
+	if err != nil {
+		if errors.Is(err, audiotype.ErrSearchQueryNotFound) {
+			msgData := util.MessageData{
+				Embeds: embeds.NotFoundEmbed(),
+				Type:   discordgo.InteractionResponseChannelMessageWithSource,
+			}
+
+			if err := util.SendMessage(session, interaction.Interaction, true, msgData, util.WithDeletion(10*time.Second, interaction.ChannelID)); err != nil {
+				return fmt.Errorf("sending follow up message: %w", err)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("searching tracks: %w", err)
+	}
+
+	sourceName := "YouTube"
+	if source == searchSourceSpotify {
+		sourceName = "Spotify"
+	}
+
+	viewConfig := &views.Config{
+		Embeds: []*discordgo.MessageEmbed{
+			embeds.SearchResultsEmbed(query, sourceName, tracks),
+		},
+		Components: &views.ComponentHandler{
+			MessageComponents: searchResultSelectMenu(tracks),
+		},
+	}
+
+	resultsView := views.NewView(viewConfig, views.WithLogger(m.logger), views.WithDeletion(searchViewTimeout))
+
+	var (
+		chosen sync.Once
+		picked atomic.Bool
+	)
+
+	respondAlreadyPicked := func(passedInteraction *discordgo.Interaction) error {
+		if err := session.InteractionRespond(passedInteraction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: "A result was already queued from this search",
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		}); err != nil {
+			return fmt.Errorf("sending ephemeral message: %w", err)
+		}
+
+		return nil
+	}
+
+	handler := func(passedInteraction *discordgo.Interaction) error {
+		if passedInteraction.Member.User.ID != interaction.Member.User.ID {
+			if err := session.InteractionRespond(passedInteraction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: "Only whoever searched can pick a result",
+					Flags:   discordgo.MessageFlagsEphemeral,
+				},
+			}); err != nil {
+				return fmt.Errorf("sending ephemeral message: %w", err)
+			}
+
+			return nil
+		}
+
+		// checked before anything else so a late pick doesn't send any other messages
+		if picked.Load() {
+			return respondAlreadyPicked(passedInteraction)
+		}
+
+		values := passedInteraction.MessageComponentData().Values
+		if len(values) == 0 {
+			return nil
+		}
+
+		index, err := strconv.Atoi(values[0])
+		if err != nil || index < 0 || index >= len(tracks) {
+			return fmt.Errorf("invalid search result %q", values[0])
+		}
+
+		// the requester may have left the voice channel while looking through the results
+		isInVoiceChannel, err := m.verifyInChannelAndSendError(session, &discordgo.InteractionCreate{Interaction: passedInteraction})
+		if err != nil {
+			return fmt.Errorf("verifying in voice channel: %w", err)
+		}
+
+		if !isInVoiceChannel {
+			return nil
+		}
+
+		handled := false
+
+		chosen.Do(func() {
+			handled = true
+			picked.Store(true)
+
+			if err = session.InteractionRespond(passedInteraction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseDeferredMessageUpdate,
+			}); err != nil {
+				err = fmt.Errorf("deferring message update: %w", err)
+				return
+			}
+
+			if err = resultsView.DeleteView(session); err != nil {
+				m.logger.Warn("unable to delete search results", zap.Error(err), logger.GuildID(interaction.GuildID))
+			}
+
+			if err = m.joinAndCreateGuildPlayer(session, interaction); err != nil {
+				err = fmt.Errorf("joining and creating guild player: %w", err)
+				return
+			}
+
+			track := tracks[index]
+			trackData := &audiotype.Data{
+				Tracks: []*audiotype.TrackData{track},
+				Type:   audioType,
+				ID:     track.ID,
+			}
+
+			if err = m.addToQueue(session, interaction, trackData, m.guildVoiceStates[interaction.GuildID]); err != nil {
+				err = fmt.Errorf("adding to queue: %w", err)
+				return
+			}
+
+			m.autocomplete.recordPlay(interaction.GuildID, interaction.Member.User.ID, track.TrackName, trackChoiceValue(track))
+		})
+
+		// another pick got there first while this one was being checked
+		if !handled {
+			return respondAlreadyPicked(passedInteraction)
+		}
+
+		return err
+	}
+
+	if err := resultsView.SendView(interaction.Interaction, session, handler); err != nil {
+		return fmt.Errorf("sending search results: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package music
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/funcs"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/trackmatch"
+	"github.com/wader/goutubedl"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	resolvedTracksCollection string = "ResolvedTracks"
+	searchQueryPrefix        string = "ytsearch"
+	// number of search results scored when resolving a track
+	searchCandidates int = 5
+	// resolved tracks are kept in memory for a while, firestore remains the source of truth
+	resolvedTrackTTL     time.Duration = time.Hour
+	maxCachedResolutions int           = 4096
+)
+
+var errNotSearchTrack = errors.New("track is not resolved through a search")
+
+type resolvedTrack struct {
+	URL        string    `firestore:"URL"`
+	TrackName  string    `firestore:"TrackName"`
+	ResolvedBy string    `firestore:"ResolvedBy"`
+	UpdatedAt  time.Time `firestore:"UpdatedAt"`
+}
+
+type videoSearcher func(ctx context.Context, track *audiotype.TrackData) (string, error)
+
+// trackResolver pins the video a search picked for a track per guild, so that
+// later plays of the same track don't repeat the search and land on a different video.
+type trackResolver struct {
+	fireStoreClient FireStore
+	search          videoSearcher
+	cache           *expiringCache[string]
+}
+
+func newTrackResolver(fs FireStore, search videoSearcher) *trackResolver {
+	return &trackResolver{
+		fireStoreClient: fs,
+		search:          search,
+		cache:           newExpiringCache[string](resolvedTrackTTL, maxCachedResolutions),
+	}
+}
+
+// isSearchTrack reports whether the track is played through a search, which is
+// the case for tracks sourced from spotify.
+func isSearchTrack(track *audiotype.TrackData) bool {
+	return track.ID != "" && strings.HasPrefix(track.Query, searchQueryPrefix)
+}
+
+func resolvedTrackKey(guildID string, trackID string) string {
+	return guildID + "/" + trackID
+}
+
+// resolve returns the query the track should be downloaded with.
+func (r *trackResolver) resolve(ctx context.Context, guildID string, track *audiotype.TrackData) (string, error) {
+	if !isSearchTrack(track) {
+		return track.Query, nil
+	}
+
+	key := resolvedTrackKey(guildID, track.ID)
+
+	url, ok := r.cache.get(key)
+	if ok {
+		return url, nil
+	}
+
+	docRef := r.fireStoreClient.GetDocumentFromCollection(ctx, guildCollection, guildID).
+		Collection(resolvedTracksCollection).
+		Doc(track.ID)
+
+	doc, err := docRef.Get(ctx)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return "", fmt.Errorf("getting resolved track: %w", err)
+	}
+
+	if err == nil {
+		var resolved resolvedTrack
+		if err := doc.DataTo(&resolved); err != nil {
+			return "", fmt.Errorf("converting data to resolvedTrack struct: %w", err)
+		}
+
+		r.cache.set(key, resolved.URL)
+
+		return resolved.URL, nil
+	}
+
+	url, err = r.search(ctx, track)
+	if err != nil {
+		return "", fmt.Errorf("searching for track: %w", err)
+	}
+
+	if _, err := docRef.Set(ctx, resolvedTrack{
+		URL:        url,
+		TrackName:  track.TrackName,
+		ResolvedBy: searchQueryPrefix,
+		UpdatedAt:  time.Now(),
+	}); err != nil {
+		return "", fmt.Errorf("saving resolved track: %w", err)
+	}
+
+	r.cache.set(key, url)
+
+	return url, nil
+}
+
+// override replaces the video a track resolves to for the whole guild.
+func (r *trackResolver) override(ctx context.Context, guildID string, userID string, track *audiotype.TrackData, url string) error {
+	if !isSearchTrack(track) {
+		return errNotSearchTrack
+	}
+
+	if _, err := r.fireStoreClient.GetDocumentFromCollection(ctx, guildCollection, guildID).
+		Collection(resolvedTracksCollection).
+		Doc(track.ID).
+		Set(ctx, resolvedTrack{
+			URL:        url,
+			TrackName:  track.TrackName,
+			ResolvedBy: userID,
+			UpdatedAt:  time.Now(),
+		}); err != nil {
+		return fmt.Errorf("saving resolved track: %w", err)
+	}
+
+	r.cache.set(resolvedTrackKey(guildID, track.ID), url)
+
+	return nil
+}
+
+// searchVideo returns the url of the search result that best matches the track,
+// scoring several results rather than trusting the first one.
+func (m *PlayerCog) searchVideo(ctx context.Context, track *audiotype.TrackData) (string, error) {
+	searchTerms := track.Query[strings.Index(track.Query, ":")+1:]
+
+	result, err := goutubedl.New(ctx, fmt.Sprintf("%s%d:%s", searchQueryPrefix, searchCandidates, searchTerms), goutubedl.Options{
+		Type:       goutubedl.TypePlaylist,
+		HTTPClient: m.httpClient,
+	})
+	if err != nil {
+		return "", fmt.Errorf("searching youtube: %w", err)
+	}
+
+	title, artist := trackmatch.ParseTrackName(track.TrackName)
+	target := trackmatch.Target{
+		Title:    title,
+		Artist:   artist,
+		Duration: track.Duration,
+	}
+
+	candidates := funcs.Map(result.Info.Entries, func(entry goutubedl.Info) trackmatch.Candidate {
+		return trackmatch.Candidate{
+			Title:    entry.Title,
+			Channel:  entry.Channel,
+			Duration: time.Duration(entry.Duration * float64(time.Second)),
+		}
+	})
+
+	best := trackmatch.Best(target, candidates)
+	if best == -1 || result.Info.Entries[best].WebpageURL == "" {
+		return "", audiotype.ErrSearchQueryNotFound
+	}
+
+	return result.Info.Entries[best].WebpageURL, nil
+}
@@ -0,0 +1,81 @@
+package testutil
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+)
+
+// RequesterName is the requester the contexts of RequesterContext carry.
+const RequesterName = "tester"
+
+// FixtureTransport answers each request with the testdata file registered for its url,
+// urls registered with another url redirect to it. Any other url is answered with a 404.
+type FixtureTransport map[string]string
+
+func (f FixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Header:     http.Header{},
+		Body:       http.NoBody,
+		Request:    req,
+	}
+
+	name, ok := f[req.URL.String()]
+	if !ok {
+		return resp, nil
+	}
+
+	if strings.HasPrefix(name, "https://") {
+		resp.StatusCode = http.StatusFound
+		resp.Header.Set("Location", name)
+
+		return resp, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		return nil, err
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	return resp, nil
+}
+
+// NewFixtureClient returns a client served by the testdata files registered for each url.
+func NewFixtureClient(fixtures map[string]string) *http.Client {
+	return &http.Client{
+		Transport: FixtureTransport(fixtures),
+	}
+}
+
+// RequesterContext returns a context carrying the requester the retrievers expect.
+func RequesterContext() context.Context {
+	return context.WithValue(context.Background(), audiotype.ContextKey("requesterName"), RequesterName)
+}
+
+// DescribeData renders retrieved track data for comparing in test failures.
+func DescribeData(data *audiotype.Data) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(string(data.Type) + " " + data.ID)
+
+	if data.PlaylistData != nil {
+		buf.WriteString(" " + data.PlaylistData.PlaylistName + " " + data.PlaylistData.PlaylistImageURL)
+	}
+
+	for _, track := range data.Tracks {
+		buf.WriteString("\n  ")
+		buf.WriteString(track.TrackName + " | " + track.Query + " | " + track.TrackImageURL + " | " + track.ID + " | " + track.Duration.String())
+	}
+
+	return buf.String()
+}
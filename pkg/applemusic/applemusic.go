@@ -0,0 +1,262 @@
+package applemusic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/pagemeta"
+)
+
+const (
+	lookupURL = "https://itunes.apple.com/lookup"
+	// ids looked up in a single request
+	lookupBatchSize = 150
+	// long playlists are cut off rather than looked up endlessly
+	maxPlaylistTracks = 500
+)
+
+var songURLRegex = regexp.MustCompile(`\/song\/(?:[^\/?#]+\/)?(\d+)`)
+
+type AppleMusicWrapper struct {
+	httpClient *http.Client
+}
+
+func NewAppleMusicWrapper(httpClient *http.Client) *AppleMusicWrapper {
+	return &AppleMusicWrapper{
+		httpClient: httpClient,
+	}
+}
+
+// lookupResult is an entry of the itunes lookup api, which describes both songs and albums.
+type lookupResult struct {
+	WrapperType     string `json:"wrapperType"`
+	TrackID         int64  `json:"trackId"`
+	TrackName       string `json:"trackName"`
+	CollectionName  string `json:"collectionName"`
+	ArtistName      string `json:"artistName"`
+	TrackTimeMillis int64  `json:"trackTimeMillis"`
+	ArtworkURL100   string `json:"artworkUrl100"`
+}
+
+// artworkURL returns a larger rendition of the artwork than the api links to.
+func (r *lookupResult) artworkURL() string {
+	return strings.Replace(r.ArtworkURL100, "100x100bb", "600x600bb", 1)
+}
+
+func (a *AppleMusicWrapper) GetTracksData(ctx context.Context, audioType audiotype.SupportedAudioType, query string) (*audiotype.Data, error) {
+	const requesterNameKey = audiotype.ContextKey("requesterName")
+
+	requesterName, ok := ctx.Value(requesterNameKey).(string)
+	if !ok {
+		return nil, errors.New("context does not have proper authorization")
+	}
+
+	match := audiotype.AppleMusicRegex.FindStringSubmatch(query)
+	if match == nil {
+		return nil, errors.New("audio type provided is not from an apple music source")
+	}
+
+	country := match[1]
+
+	switch audioType {
+	case audiotype.AppleMusicTrack:
+		return a.handleSingleTrack(ctx, requesterName, country, audiotype.AppleMusicTrackID(query))
+	case audiotype.AppleMusicAlbum:
+		return a.handleAlbum(ctx, requesterName, country, match[3])
+	case audiotype.AppleMusicPlaylist:
+		return a.handlePlaylist(ctx, requesterName, query, match[3])
+	}
+
+	return nil, errors.New("audio type provided is not from an apple music source")
+}
+
+func (a *AppleMusicWrapper) lookup(ctx context.Context, country string, ids []string, entity string) ([]lookupResult, error) {
+	params := url.Values{}
+	params.Set("id", strings.Join(ids, ","))
+	params.Set("country", country)
+
+	if entity != "" {
+		params.Set("entity", entity)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, lookupURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting itunes lookup: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("requesting itunes lookup: status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Results []lookupResult `json:"results"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding itunes lookup: %w", err)
+	}
+
+	return body.Results, nil
+}
+
+func newTrackData(result *lookupResult, requesterName string) *audiotype.TrackData {
+	trackTitle := result.TrackName + " - " + result.ArtistName
+
+	return &audiotype.TrackData{
+		TrackName:     trackTitle,
+		TrackImageURL: result.artworkURL(),
+		Query:         "ytsearch1:" + trackTitle,
+		Requester:     requesterName,
+		Duration:      time.Duration(result.TrackTimeMillis) * time.Millisecond,
+		ID:            fmt.Sprintf("apple-%d", result.TrackID),
+	}
+}
+
+func (a *AppleMusicWrapper) handleSingleTrack(ctx context.Context, requesterName string, country string, trackID string) (*audiotype.Data, error) {
+	results, err := a.lookup(ctx, country, []string{trackID}, "")
+	if err != nil {
+		return nil, fmt.Errorf("getting track: %w", err)
+	}
+
+	for _, result := range results {
+		if result.WrapperType == "track" {
+			return &audiotype.Data{
+				Tracks: []*audiotype.TrackData{newTrackData(&result, requesterName)},
+				Type:   audiotype.AppleMusicTrack,
+				ID:     trackID,
+			}, nil
+		}
+	}
+
+	return nil, audiotype.ErrSearchQueryNotFound
+}
+
+func (a *AppleMusicWrapper) handleAlbum(ctx context.Context, requesterName string, country string, albumID string) (*audiotype.Data, error) {
+	results, err := a.lookup(ctx, country, []string{albumID}, "song")
+	if err != nil {
+		return nil, fmt.Errorf("getting album: %w", err)
+	}
+
+	playlistData := &audiotype.PlaylistData{}
+	trackData := []*audiotype.TrackData{}
+
+	for _, result := range results {
+		switch result.WrapperType {
+		case "collection":
+			playlistData.PlaylistName = result.CollectionName
+			playlistData.PlaylistImageURL = result.artworkURL()
+		case "track":
+			trackData = append(trackData, newTrackData(&result, requesterName))
+		}
+	}
+
+	if len(trackData) == 0 {
+		return nil, audiotype.ErrSearchQueryNotFound
+	}
+
+	return &audiotype.Data{
+		Tracks:       trackData,
+		Type:         audiotype.AppleMusicAlbum,
+		PlaylistData: playlistData,
+		ID:           albumID,
+	}, nil
+}
+
+// handlePlaylist reads the tracks from the playlist's public page, as playlists aren't
+// available through the lookup api. The songs found are looked up for their artwork.
+func (a *AppleMusicWrapper) handlePlaylist(ctx context.Context, requesterName string, query string, playlistID string) (*audiotype.Data, error) {
+	page, err := pagemeta.Fetch(ctx, a.httpClient, query)
+	if err != nil {
+		return nil, fmt.Errorf("getting playlist page: %w", err)
+	}
+
+	collection, ok := page.Collection()
+	if !ok || len(collection.Tracks) == 0 {
+		return nil, audiotype.ErrSearchQueryNotFound
+	}
+
+	recordings := collection.Tracks
+	if len(recordings) > maxPlaylistTracks {
+		recordings = recordings[:maxPlaylistTracks]
+	}
+
+	songIDs := make([]string, 0, len(recordings))
+	for _, recording := range recordings {
+		if match := songURLRegex.FindStringSubmatch(recording.URL); match != nil {
+			songIDs = append(songIDs, match[1])
+		}
+	}
+
+	country := audiotype.AppleMusicRegex.FindStringSubmatch(query)[1]
+	songs := make(map[string]*lookupResult, len(songIDs))
+
+	for start := 0; start < len(songIDs); start += lookupBatchSize {
+		results, err := a.lookup(ctx, country, songIDs[start:min(start+lookupBatchSize, len(songIDs))], "")
+		if err != nil {
+			return nil, fmt.Errorf("getting playlist tracks: %w", err)
+		}
+
+		for _, result := range results {
+			if result.WrapperType == "track" {
+				songs[fmt.Sprint(result.TrackID)] = &result
+			}
+		}
+	}
+
+	trackData := make([]*audiotype.TrackData, 0, len(recordings))
+
+	for _, recording := range recordings {
+		if match := songURLRegex.FindStringSubmatch(recording.URL); match != nil {
+			if song, ok := songs[match[1]]; ok {
+				trackData = append(trackData, newTrackData(song, requesterName))
+				continue
+			}
+		}
+
+		// songs unavailable in the storefront still carry their name on the page, they're
+		// left without an id as there's nothing stable to remember their match by
+		trackTitle := recording.Name
+		if artist := recording.Artist(); artist != "" {
+			trackTitle += " - " + artist
+		}
+
+		trackData = append(trackData, &audiotype.TrackData{
+			TrackName: trackTitle,
+			Query:     "ytsearch1:" + trackTitle,
+			Requester: requesterName,
+			Duration:  pagemeta.ParseISODuration(recording.Duration),
+		})
+	}
+
+	imageURL := collection.ImageURL()
+	if imageURL == "" {
+		imageURL = page.Meta["og:image"]
+	}
+
+	return &audiotype.Data{
+		Tracks: trackData,
+		Type:   audiotype.AppleMusicPlaylist,
+		PlaylistData: &audiotype.PlaylistData{
+			PlaylistName:     collection.Name,
+			PlaylistImageURL: imageURL,
+		},
+		ID: playlistID,
+	}, nil
+}
@@ -0,0 +1,167 @@
+package applemusic
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/internal/testutil"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+)
+
+func newTestWrapper() *AppleMusicWrapper {
+	return NewAppleMusicWrapper(testutil.NewFixtureClient(map[string]string{
+		"https://itunes.apple.com/lookup?country=us&id=1499378615":              "lookup_song.json",
+		"https://itunes.apple.com/lookup?country=us&entity=song&id=1499378108":  "lookup_album.json",
+		"https://itunes.apple.com/lookup?country=us&id=404":                     "lookup_empty.json",
+		"https://itunes.apple.com/lookup?country=us&id=1440818839%2C1111111111": "lookup_playlist_songs.json",
+		"https://music.apple.com/us/playlist/friday-night-mix/pl.u-friday":      "playlist.html",
+		"https://music.apple.com/us/playlist/private/pl.u-private":              "playlist_empty.html",
+	}))
+}
+
+func TestGetTracksData(t *testing.T) {
+	const artworkURL = "https://is1-ssl.mzstatic.com/image/thumb/Music/after-hours/600x600bb.jpg"
+
+	blindingLights := &audiotype.TrackData{
+		TrackName:     "Blinding Lights - The Weeknd",
+		TrackImageURL: artworkURL,
+		Query:         "ytsearch1:Blinding Lights - The Weeknd",
+		Requester:     testutil.RequesterName,
+		Duration:      200040 * time.Millisecond,
+		ID:            "apple-1499378615",
+	}
+
+	tests := []struct {
+		name      string
+		audioType audiotype.SupportedAudioType
+		query     string
+		want      *audiotype.Data
+		wantErr   error
+	}{
+		{
+			name:      "song link",
+			audioType: audiotype.AppleMusicTrack,
+			query:     "https://music.apple.com/us/song/blinding-lights/1499378615",
+			want: &audiotype.Data{
+				Tracks: []*audiotype.TrackData{blindingLights},
+				Type:   audiotype.AppleMusicTrack,
+				ID:     "1499378615",
+			},
+		},
+		{
+			name:      "album link shared from a song",
+			audioType: audiotype.AppleMusicTrack,
+			query:     "https://music.apple.com/us/album/after-hours/1499378108?i=1499378615",
+			want: &audiotype.Data{
+				Tracks: []*audiotype.TrackData{blindingLights},
+				Type:   audiotype.AppleMusicTrack,
+				ID:     "1499378615",
+			},
+		},
+		{
+			name:      "album",
+			audioType: audiotype.AppleMusicAlbum,
+			query:     "https://music.apple.com/us/album/after-hours/1499378108",
+			want: &audiotype.Data{
+				Tracks: []*audiotype.TrackData{
+					{
+						TrackName:     "Alone Again - The Weeknd",
+						TrackImageURL: artworkURL,
+						Query:         "ytsearch1:Alone Again - The Weeknd",
+						Requester:     testutil.RequesterName,
+						Duration:      250051 * time.Millisecond,
+						ID:            "apple-1499378609",
+					},
+					blindingLights,
+				},
+				Type: audiotype.AppleMusicAlbum,
+				PlaylistData: &audiotype.PlaylistData{
+					PlaylistName:     "After Hours",
+					PlaylistImageURL: artworkURL,
+				},
+				ID: "1499378108",
+			},
+		},
+		{
+			name:      "playlist",
+			audioType: audiotype.AppleMusicPlaylist,
+			query:     "https://music.apple.com/us/playlist/friday-night-mix/pl.u-friday",
+			want: &audiotype.Data{
+				Tracks: []*audiotype.TrackData{
+					{
+						TrackName:     "One More Time - Daft Punk",
+						TrackImageURL: "https://is1-ssl.mzstatic.com/image/thumb/Music/discovery/600x600bb.jpg",
+						Query:         "ytsearch1:One More Time - Daft Punk",
+						Requester:     testutil.RequesterName,
+						Duration:      320357 * time.Millisecond,
+						ID:            "apple-1440818839",
+					},
+					{
+						TrackName: "Region Locked Song - Somebody",
+						Query:     "ytsearch1:Region Locked Song - Somebody",
+						Requester: testutil.RequesterName,
+						Duration:  3*time.Minute + 5*time.Second,
+					},
+					{
+						TrackName: "Nameless Artist Track",
+						Query:     "ytsearch1:Nameless Artist Track",
+						Requester: testutil.RequesterName,
+						Duration:  2 * time.Minute,
+					},
+				},
+				Type: audiotype.AppleMusicPlaylist,
+				PlaylistData: &audiotype.PlaylistData{
+					PlaylistName:     "Friday Night Mix",
+					PlaylistImageURL: "https://is1-ssl.mzstatic.com/image/thumb/playlist/og.jpg",
+				},
+				ID: "pl.u-friday",
+			},
+		},
+		{
+			name:      "song missing from the storefront",
+			audioType: audiotype.AppleMusicTrack,
+			query:     "https://music.apple.com/us/song/missing/404",
+			wantErr:   audiotype.ErrSearchQueryNotFound,
+		},
+		{
+			name:      "playlist page without tracks",
+			audioType: audiotype.AppleMusicPlaylist,
+			query:     "https://music.apple.com/us/playlist/private/pl.u-private",
+			wantErr:   audiotype.ErrSearchQueryNotFound,
+		},
+	}
+
+	wrapper := newTestWrapper()
+	ctx := testutil.RequesterContext()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wrapper.GetTracksData(ctx, tt.audioType, tt.query)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetTracksData() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GetTracksData() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTracksData() = %s, want %s", testutil.DescribeData(got), testutil.DescribeData(tt.want))
+			}
+		})
+	}
+}
+
+func TestGetTracksDataRequiresRequester(t *testing.T) {
+	_, err := newTestWrapper().GetTracksData(context.Background(), audiotype.AppleMusicTrack, "https://music.apple.com/us/song/blinding-lights/1499378615")
+	if err == nil {
+		t.Fatal("GetTracksData() without a requester succeeded")
+	}
+}
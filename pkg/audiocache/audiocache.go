@@ -0,0 +1,400 @@
+package audiocache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	fileExtension = ".audio"
+	partialPrefix = "partial-"
+)
+
+var ErrTooLarge = errors.New("file exceeds the cache size")
+
+// Cache is an on-disk cache of downloaded audio, once the files exceed the
+// configured size the least recently used ones are evicted. Files handed out by
+// Get and Add are pinned, they aren't evicted until every pin is released.
+type Cache struct {
+	dir      string
+	maxBytes int64
+	mu       sync.Mutex
+	entries  map[string]*list.Element
+	lru      *list.List
+	size     int64
+	hits     atomic.Int64
+	misses   atomic.Int64
+}
+
+type entry struct {
+	name string
+	size int64
+	// pins counts the holders of the file that may still open it
+	pins int
+}
+
+// Pin keeps a cached file from being evicted until it is released.
+type Pin struct {
+	cache *Cache
+	name  string
+	once  sync.Once
+	// Path is where the pinned file is stored
+	Path string
+}
+
+// Release allows the file to be evicted again, releasing a pin more than once has no effect.
+func (p *Pin) Release() {
+	p.once.Do(func() {
+		p.cache.unpin(p.name)
+	})
+}
+
+type Stats struct {
+	Hits    int64
+	Misses  int64
+	Entries int
+	Bytes   int64
+}
+
+// HitRate returns the share of lookups that were served from the cache.
+func (s Stats) HitRate() float64 {
+	lookups := s.Hits + s.Misses
+	if lookups == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(lookups)
+}
+
+// New creates a cache storing files in dir, files already present from a previous
+// run are indexed by their modification time.
+func New(dir string, maxBytes int64) (*Cache, error) {
+	if maxBytes <= 0 {
+		return nil, fmt.Errorf("invalid cache size %d", maxBytes)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating cache directory: %w", err)
+	}
+
+	cache := &Cache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+
+	if err := cache.load(); err != nil {
+		return nil, fmt.Errorf("loading cache directory: %w", err)
+	}
+
+	return cache, nil
+}
+
+func (c *Cache) load() error {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("reading directory: %w", err)
+	}
+
+	type cachedFile struct {
+		name    string
+		size    int64
+		modTime time.Time
+	}
+
+	files := make([]cachedFile, 0, len(dirEntries))
+
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if dirEntry.IsDir() {
+			continue
+		}
+
+		// partial files are left behind by writes that were interrupted
+		if strings.HasPrefix(name, partialPrefix) {
+			_ = os.Remove(filepath.Join(c.dir, name))
+			continue
+		}
+
+		if !strings.HasSuffix(name, fileExtension) {
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			return fmt.Errorf("describing %s: %w", name, err)
+		}
+
+		files = append(files, cachedFile{name: name, size: info.Size(), modTime: info.ModTime()})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.After(files[j].modTime)
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, file := range files {
+		c.entries[file.name] = c.lru.PushBack(&entry{name: file.name, size: file.size})
+		c.size += file.size
+	}
+
+	c.evict()
+
+	return nil
+}
+
+func fileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:]) + fileExtension
+}
+
+// Get pins the file cached for key and marks it as recently used, the pin must be
+// released once the file is no longer needed.
+func (c *Cache) Get(key string) (*Pin, bool) {
+	name := fileName(key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[name]
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	c.hits.Add(1)
+	c.lru.MoveToFront(element)
+	element.Value.(*entry).pins++
+
+	path := filepath.Join(c.dir, name)
+	// keep the recency across restarts, failing to do so only affects eviction order
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	return &Pin{cache: c, name: name, Path: path}, true
+}
+
+// Add moves the file at srcPath into the cache under key and pins it, the pin must be
+// released once the file is no longer needed.
+func (c *Cache) Add(key string, srcPath string) (*Pin, error) {
+	info, err := os.Stat(srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("describing file: %w", err)
+	}
+
+	if info.Size() > c.maxBytes {
+		return nil, ErrTooLarge
+	}
+
+	name := fileName(key)
+	path := filepath.Join(c.dir, name)
+
+	if err := os.Rename(srcPath, path); err != nil {
+		// renaming fails across file systems, fall back to copying
+		if err := c.copyFile(srcPath, path); err != nil {
+			return nil, err
+		}
+
+		_ = os.Remove(srcPath)
+	}
+
+	c.insert(name, info.Size())
+
+	return &Pin{cache: c, name: name, Path: path}, nil
+}
+
+func (c *Cache) copyFile(srcPath string, dstPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("opening file: %w", err)
+	}
+
+	defer func() {
+		_ = src.Close()
+	}()
+
+	writer, err := c.newPartialFile()
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(writer, src); err != nil {
+		_ = writer.Close()
+		_ = os.Remove(writer.Name())
+
+		return fmt.Errorf("copying file content: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		_ = os.Remove(writer.Name())
+
+		return fmt.Errorf("closing file: %w", err)
+	}
+
+	if err := os.Rename(writer.Name(), dstPath); err != nil {
+		_ = os.Remove(writer.Name())
+
+		return fmt.Errorf("renaming file: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Cache) newPartialFile() (*os.File, error) {
+	file, err := os.CreateTemp(c.dir, partialPrefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("creating partial file: %w", err)
+	}
+
+	return file, nil
+}
+
+// insert records the file and pins it for the caller of Add.
+func (c *Cache) insert(name string, size int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[name]; ok {
+		existing := element.Value.(*entry)
+		c.size += size - existing.size
+		existing.size = size
+		existing.pins++
+		c.lru.MoveToFront(element)
+	} else {
+		c.entries[name] = c.lru.PushFront(&entry{name: name, size: size, pins: 1})
+		c.size += size
+	}
+
+	c.evict()
+}
+
+func (c *Cache) unpin(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[name]
+	if !ok {
+		return
+	}
+
+	element.Value.(*entry).pins--
+
+	// the cache may have grown past its size while the file couldn't be evicted
+	c.evict()
+}
+
+// evict removes the least recently used files that aren't pinned until the cache
+// fits its size, the caller must hold the lock.
+func (c *Cache) evict() {
+	for element := c.lru.Back(); element != nil && c.size > c.maxBytes; {
+		evicted := element.Value.(*entry)
+		previous := element.Prev()
+
+		if evicted.pins == 0 {
+			c.lru.Remove(element)
+			delete(c.entries, evicted.name)
+			c.size -= evicted.size
+
+			_ = os.Remove(filepath.Join(c.dir, evicted.name))
+		}
+
+		element = previous
+	}
+}
+
+// Stats returns the current usage of the cache.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return Stats{
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Entries: c.lru.Len(),
+		Bytes:   c.size,
+	}
+}
+
+// Writer stores data as it is written and adds it to the cache on Commit.
+// Write errors are recorded rather than returned, so a failing cache never
+// interrupts the reader it is teed from.
+type Writer struct {
+	cache *Cache
+	key   string
+	file  *os.File
+	size  int64
+	err   error
+}
+
+// NewWriter creates a writer that caches the data written to it under key.
+func (c *Cache) NewWriter(key string) (*Writer, error) {
+	file, err := c.newPartialFile()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{cache: c, key: key, file: file}, nil
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return len(p), nil
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+
+	if err == nil && w.size > w.cache.maxBytes {
+		err = ErrTooLarge
+	}
+
+	if err != nil {
+		w.err = err
+	}
+
+	return len(p), nil
+}
+
+// Commit adds the written data to the cache.
+func (w *Writer) Commit() error {
+	if w.err != nil {
+		w.Abort()
+		return fmt.Errorf("writing cached file: %w", w.err)
+	}
+
+	if err := w.file.Close(); err != nil {
+		_ = os.Remove(w.file.Name())
+		return fmt.Errorf("closing cached file: %w", err)
+	}
+
+	pin, err := w.cache.Add(w.key, w.file.Name())
+	if err != nil {
+		_ = os.Remove(w.file.Name())
+		return fmt.Errorf("adding file to cache: %w", err)
+	}
+
+	pin.Release()
+
+	return nil
+}
+
+// Abort discards the written data.
+func (w *Writer) Abort() {
+	_ = w.file.Close()
+	_ = os.Remove(w.file.Name())
+}
@@ -0,0 +1,72 @@
+package audiocache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func addFile(t *testing.T, cache *Cache, key string, size int) *Pin {
+	t.Helper()
+
+	srcPath := filepath.Join(t.TempDir(), key)
+	if err := os.WriteFile(srcPath, make([]byte, size), 0o600); err != nil {
+		t.Fatalf("writing %s: %v", key, err)
+	}
+
+	pin, err := cache.Add(key, srcPath)
+	if err != nil {
+		t.Fatalf("Add(%q) error = %v", key, err)
+	}
+
+	return pin
+}
+
+func TestPinnedFilesAreNotEvicted(t *testing.T) {
+	cache, err := New(t.TempDir(), 10)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	first := addFile(t, cache, "first", 6)
+	second := addFile(t, cache, "second", 6)
+
+	// both files are pinned, so the cache is allowed to exceed its size
+	for _, pin := range []*Pin{first, second} {
+		if _, err := os.Stat(pin.Path); err != nil {
+			t.Errorf("pinned file was evicted: %v", err)
+		}
+	}
+
+	first.Release()
+	first.Release()
+
+	if _, err := os.Stat(first.Path); !os.IsNotExist(err) {
+		t.Errorf("released file was kept past the cache size, stat error = %v", err)
+	}
+
+	if _, ok := cache.Get("first"); ok {
+		t.Error("Get() found the evicted file")
+	}
+
+	pin, ok := cache.Get("second")
+	if !ok {
+		t.Fatal("Get() didn't find the pinned file")
+	}
+
+	second.Release()
+
+	// the lookup pinned the file again
+	if stats := cache.Stats(); stats.Entries != 1 || stats.Bytes != 6 {
+		t.Errorf("Stats() = %+v, want 1 entry of 6 bytes", stats)
+	}
+
+	pin.Release()
+
+	third := addFile(t, cache, "third", 6)
+	defer third.Release()
+
+	if _, err := os.Stat(pin.Path); !os.IsNotExist(err) {
+		t.Errorf("unpinned file wasn't evicted, stat error = %v", err)
+	}
+}
@@ -0,0 +1,211 @@
+package audiofile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+)
+
+var (
+	ErrNoAudioStream = errors.New("file does not contain an audio stream")
+	ErrNoCoverArt    = errors.New("file does not contain cover art")
+)
+
+// covers larger than this wouldn't fit within discord's upload limit
+const maxCoverArtSize = 8 * 1024 * 1024
+
+type CoverArt struct {
+	Data        []byte
+	ContentType string
+}
+
+type Metadata struct {
+	Title    string
+	Artist   string
+	Album    string
+	Duration time.Duration
+}
+
+type probeTags map[string]string
+
+// get looks up a tag case insensitively, since the casing differs between containers.
+func (t probeTags) get(name string) string {
+	for key, value := range t {
+		if strings.EqualFold(key, name) {
+			return strings.TrimSpace(value)
+		}
+	}
+
+	return ""
+}
+
+type probeOutput struct {
+	Format struct {
+		Duration string    `json:"duration"`
+		Tags     probeTags `json:"tags"`
+	} `json:"format"`
+	Streams []struct {
+		Tags probeTags `json:"tags"`
+	} `json:"streams"`
+}
+
+// Probe reads the duration and tags of an audio file or url with ffprobe.
+func Probe(ctx context.Context, source string) (*Metadata, error) {
+	output, err := exec.CommandContext(ctx, "ffprobe",
+		"-v", "quiet",
+		"-print_format", "json",
+		"-show_format",
+		"-show_streams",
+		"-select_streams", "a",
+		source,
+	).Output()
+	if err != nil {
+		return nil, fmt.Errorf("running ffprobe: %w", err)
+	}
+
+	var probe probeOutput
+	if err := json.Unmarshal(output, &probe); err != nil {
+		return nil, fmt.Errorf("decoding ffprobe output: %w", err)
+	}
+
+	if len(probe.Streams) == 0 {
+		return nil, ErrNoAudioStream
+	}
+
+	metadata := &Metadata{
+		Title:  probe.Format.Tags.get("title"),
+		Artist: probe.Format.Tags.get("artist"),
+		Album:  probe.Format.Tags.get("album"),
+	}
+
+	// ogg and opus files keep their tags on the stream rather than the container
+	if metadata.Title == "" {
+		metadata.Title = probe.Streams[0].Tags.get("title")
+	}
+
+	if metadata.Artist == "" {
+		metadata.Artist = probe.Streams[0].Tags.get("artist")
+	}
+
+	if metadata.Album == "" {
+		metadata.Album = probe.Streams[0].Tags.get("album")
+	}
+
+	if seconds, err := strconv.ParseFloat(probe.Format.Duration, 64); err == nil {
+		metadata.Duration = time.Duration(seconds * float64(time.Second))
+	}
+
+	return metadata, nil
+}
+
+// ExtractCoverArt copies the artwork embedded in an audio file out with ffmpeg, as it is
+// stored without re-encoding it.
+func ExtractCoverArt(ctx context.Context, source string) (*CoverArt, error) {
+	output, err := exec.CommandContext(ctx, "ffmpeg",
+		"-v", "quiet",
+		"-i", source,
+		"-an",
+		"-vcodec", "copy",
+		"-frames:v", "1",
+		"-f", "image2pipe",
+		"-",
+	).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		// ffmpeg fails when the file has no picture to copy
+		if errors.As(err, &exitErr) && ctx.Err() == nil {
+			return nil, ErrNoCoverArt
+		}
+
+		return nil, fmt.Errorf("running ffmpeg: %w", err)
+	}
+
+	contentType := http.DetectContentType(output)
+	if len(output) == 0 || len(output) > maxCoverArtSize || !strings.HasPrefix(contentType, "image/") {
+		return nil, ErrNoCoverArt
+	}
+
+	return &CoverArt{
+		Data:        output,
+		ContentType: contentType,
+	}, nil
+}
+
+// DirectFileWrapper retrieves tracks for links pointing straight at audio files,
+// such as discord attachments.
+type DirectFileWrapper struct{}
+
+func NewDirectFileWrapper() *DirectFileWrapper {
+	return &DirectFileWrapper{}
+}
+
+func (d *DirectFileWrapper) GetTracksData(ctx context.Context, audioType audiotype.SupportedAudioType, query string) (*audiotype.Data, error) {
+	const requesterNameKey = audiotype.ContextKey("requesterName")
+
+	requesterName, ok := ctx.Value(requesterNameKey).(string)
+	if !ok {
+		return nil, errors.New("context does not have proper authorization")
+	}
+
+	if audioType != audiotype.DirectAudioFile {
+		return nil, errors.New("audio type provided is not a direct audio file")
+	}
+
+	metadata, err := Probe(ctx, query)
+	if err != nil {
+		if errors.Is(err, ErrNoAudioStream) {
+			return nil, audiotype.ErrUnsupportedAudioType
+		}
+
+		return nil, fmt.Errorf("probing audio file: %w", err)
+	}
+
+	fileName := fileNameFromURL(query)
+
+	trackName := fileName
+	if metadata.Title != "" {
+		trackName = metadata.Title
+		if metadata.Artist != "" {
+			trackName += " - " + metadata.Artist
+		}
+	}
+
+	return &audiotype.Data{
+		Tracks: []*audiotype.TrackData{
+			{
+				TrackName: trackName,
+				Query:     query,
+				Requester: requesterName,
+				Duration:  metadata.Duration,
+				ID:        fileName,
+				AudioType: audiotype.DirectAudioFile,
+			},
+		},
+		Type: audiotype.DirectAudioFile,
+		ID:   fileName,
+	}, nil
+}
+
+func fileNameFromURL(fileURL string) string {
+	parsedURL, err := url.Parse(fileURL)
+	if err != nil {
+		return fileURL
+	}
+
+	fileName, err := url.PathUnescape(path.Base(parsedURL.Path))
+	if err != nil {
+		return path.Base(parsedURL.Path)
+	}
+
+	return fileName
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -20,6 +22,12 @@ type TrackData struct {
 	Requester     string        `firestore:"requester"`
 	Duration      time.Duration `firestore:"duration"`
 	ID            string        `firestore:"ID"`
+	// AudioType is only set for tracks that are played without yt-dlp.
+	AudioType SupportedAudioType `firestore:"audio_type,omitempty"`
+	// RequesterID is the user id of whoever queued the track, it's set once the track is queued.
+	RequesterID string `firestore:"requester_id,omitempty"`
+	// StartAt is where playback starts, for links pointing at a moment in a video.
+	StartAt time.Duration `firestore:"start_at,omitempty"`
 }
 type PlaylistData struct {
 	PlaylistName     string `firestore:"playlist_name"`
@@ -36,30 +44,69 @@ type Data struct {
 const (
 	YoutubeSong        SupportedAudioType = "YoutubeSongAudio"
 	YoutubePlaylist    SupportedAudioType = "YoutubePlaylistAudio"
+	YoutubeMix         SupportedAudioType = "YoutubeMixAudio"
 	SpotifyTrack       SupportedAudioType = "SpotifyTrackAudio"
 	SpotifyPlaylist    SupportedAudioType = "SpotifyPlaylistAudio"
 	SpotifyAlbum       SupportedAudioType = "SpotifyAlbumAudio"
+	SpotifyArtist      SupportedAudioType = "SpotifyArtistAudio"
+	SpotifyShow        SupportedAudioType = "SpotifyShowAudio"
+	SpotifyEpisode     SupportedAudioType = "SpotifyEpisodeAudio"
+	SpotifyShortLink   SupportedAudioType = "SpotifyShortLinkAudio"
 	SoundCloudTrack    SupportedAudioType = "SoundCloud"
 	SoundCloudPlaylist SupportedAudioType = "SoundCloudPlaylistAudio"
 	GenericSearch      SupportedAudioType = "GenericSearchAudio"
+	GenericURL         SupportedAudioType = "GenericURLAudio"
+	DirectAudioFile    SupportedAudioType = "DirectAudioFileAudio"
+	LiveRadio          SupportedAudioType = "LiveRadioAudio"
+	PodcastEpisode     SupportedAudioType = "PodcastEpisodeAudio"
+	LibraryTrack       SupportedAudioType = "LibraryTrackAudio"
+	AppleMusicTrack    SupportedAudioType = "AppleMusicTrackAudio"
+	AppleMusicAlbum    SupportedAudioType = "AppleMusicAlbumAudio"
+	AppleMusicPlaylist SupportedAudioType = "AppleMusicPlaylistAudio"
+	DeezerTrack        SupportedAudioType = "DeezerTrackAudio"
+	DeezerAlbum        SupportedAudioType = "DeezerAlbumAudio"
+	DeezerPlaylist     SupportedAudioType = "DeezerPlaylistAudio"
+	// DeezerShortLink is a shared link that only reveals what it points at once followed
+	DeezerShortLink SupportedAudioType = "DeezerShortLinkAudio"
+	TidalTrack      SupportedAudioType = "TidalTrackAudio"
+	TidalAlbum      SupportedAudioType = "TidalAlbumAudio"
+	TidalPlaylist   SupportedAudioType = "TidalPlaylistAudio"
 )
 
+// LibraryPrefix marks queries that search the local music library.
+const LibraryPrefix = "library:"
+
 var (
-	YoutubeVideoRegex    = regexp.MustCompile(`^(?:https?:\/\/)?(?:www\.)?(?:youtube\.com\/(?:[^\/\n\s]+\/\S+\/|(?:v|e(?:mbed)?)\/|.*[?&]v=)|youtu\.be\/)([a-zA-Z0-9_-]{11})(?:\S+)?$`)
-	YoutubePlaylistRegex = regexp.MustCompile(`[?&]list=([a-zA-Z0-9_-]+)`)
-	SpotifyAlbumRegex    = regexp.MustCompile(`https:\/\/open\.spotify\.com\/album\/([a-zA-Z0-9]+)`)
-	SpotifyPlaylistRegex = regexp.MustCompile(`https:\/\/open\.spotify\.com\/playlist\/([a-zA-Z0-9]+)`)
-	SpotifyTrackRegex    = regexp.MustCompile(`https:\/\/open\.spotify\.com\/track\/([a-zA-Z0-9]+)`)
-	SoundCloudRegex      = regexp.MustCompile(`^https?:\/\/(soundcloud\.com|snd\.sc)\/(.*)$`)
-	SoundCloudSetsRegex  = regexp.MustCompile(`sets`)
+	// also matches YouTube Music, mobile, Shorts and live links
+	YoutubeVideoRegex     = regexp.MustCompile(`^(?:https?:\/\/)?(?:(?:www|m|music)\.)?(?:youtube\.com\/(?:[^\/\n\s]+\/\S+\/|(?:v|e(?:mbed)?|shorts|live)\/|.*[?&]v=)|youtu\.be\/)([a-zA-Z0-9_-]{11})(?:\S+)?$`)
+	YoutubePlaylistRegex  = regexp.MustCompile(`[?&]list=([a-zA-Z0-9_-]+)`)
+	youtubeTimestampRegex = regexp.MustCompile(`^(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s?)?$`)
+	// links may carry a locale such as intl-de, or point at the embedded player
+	SpotifyLinkRegex      = regexp.MustCompile(`^https?:\/\/(?:open|play)\.spotify\.com\/(?:intl-[a-zA-Z_-]+\/)?(?:embed\/)?(?:user\/[^\/?#\s]+\/)?(track|album|playlist|artist|show|episode)\/([a-zA-Z0-9]+)`)
+	SpotifyURIRegex       = regexp.MustCompile(`^spotify:(?:user:[^:\s]+:)?(track|album|playlist|artist|show|episode):([a-zA-Z0-9]+)$`)
+	SpotifyShortLinkRegex = regexp.MustCompile(`^https?:\/\/spotify\.(?:app\.)?link\/\S+$`)
+	SoundCloudRegex       = regexp.MustCompile(`^https?:\/\/(soundcloud\.com|snd\.sc)\/(.*)$`)
+	SoundCloudSetsRegex   = regexp.MustCompile(`sets`)
+	DirectAudioFileRegex  = regexp.MustCompile(`(?i)^https?:\/\/[^\s?#]+\.(?:mp3|flac|ogg|wav|m4a)(?:[?#]\S*)?$`)
+	DiscordCDNRegex       = regexp.MustCompile(`(?i)^https?:\/\/(?:cdn\.discordapp\.com|media\.discordapp\.net)\/attachments\/`)
+	AppleMusicRegex       = regexp.MustCompile(`^https?:\/\/(?:geo\.)?music\.apple\.com\/([a-z]{2})\/(album|song|playlist)\/(?:[^\/?#\s]+\/)?([^\/?#\s]+)`)
+	DeezerRegex           = regexp.MustCompile(`^https?:\/\/(?:www\.)?deezer\.com\/(?:[a-z]{2}(?:-[a-z]{2})?\/)?(track|album|playlist)\/(\d+)`)
+	DeezerShortLinkRegex  = regexp.MustCompile(`^https?:\/\/(?:deezer\.page\.link|link\.deezer\.com)\/\S+$`)
+	TidalRegex            = regexp.MustCompile(`^https?:\/\/(?:www\.|listen\.)?tidal\.com\/(?:browse\/)?(track|album|playlist)\/([a-zA-Z0-9-]+)`)
 )
 
 var (
 	ErrUnsupportedAudioType = errors.New("search query provided is not a supported audio type")
 	ErrSearchQueryNotFound  = errors.New("search query could not be resolved")
+	ErrInvalidTimestamp     = errors.New("timestamp provided is not valid")
 )
 
 func DetermineAudioType(query string) (SupportedAudioType, error) {
+	// Local music library
+	if strings.HasPrefix(strings.ToLower(query), LibraryPrefix) {
+		return LibraryTrack, nil
+	}
+
 	// YouTube Video (prioritize video ID first)
 	if YoutubeVideoRegex.MatchString(query) {
 		return YoutubeSong, nil
@@ -67,22 +114,12 @@ func DetermineAudioType(query string) (SupportedAudioType, error) {
 
 	// YouTube Playlist (only match if list= is in the URL)
 	if YoutubePlaylistRegex.MatchString(query) {
-		return YoutubePlaylist, nil
+		return YoutubeListType(query), nil
 	}
 
-	// Spotify Playlist
-	if SpotifyPlaylistRegex.MatchString(query) {
-		return SpotifyPlaylist, nil
-	}
-
-	// Spotify Album
-	if SpotifyAlbumRegex.MatchString(query) {
-		return SpotifyAlbum, nil
-	}
-
-	// Spotify Track
-	if SpotifyTrackRegex.MatchString(query) {
-		return SpotifyTrack, nil
+	// Spotify
+	if audioType, _, ok := ParseSpotifyLink(query); ok {
+		return audioType, nil
 	}
 
 	// SoundCloud
@@ -93,25 +130,208 @@ func DetermineAudioType(query string) (SupportedAudioType, error) {
 		return SoundCloudTrack, nil
 	}
 
+	// Apple Music
+	if match := AppleMusicRegex.FindStringSubmatch(query); match != nil {
+		switch {
+		case match[2] == "song", match[2] == "album" && appleMusicTrackParam(query) != "":
+			return AppleMusicTrack, nil
+		case match[2] == "album":
+			return AppleMusicAlbum, nil
+		default:
+			return AppleMusicPlaylist, nil
+		}
+	}
+
+	// Deezer
+	if match := DeezerRegex.FindStringSubmatch(query); match != nil {
+		switch match[1] {
+		case "track":
+			return DeezerTrack, nil
+		case "album":
+			return DeezerAlbum, nil
+		default:
+			return DeezerPlaylist, nil
+		}
+	}
+
+	if DeezerShortLinkRegex.MatchString(query) {
+		return DeezerShortLink, nil
+	}
+
+	// Tidal
+	if match := TidalRegex.FindStringSubmatch(query); match != nil {
+		switch match[1] {
+		case "track":
+			return TidalTrack, nil
+		case "album":
+			return TidalAlbum, nil
+		default:
+			return TidalPlaylist, nil
+		}
+	}
+
+	// Links straight to audio files, such as discord attachments
+	if DirectAudioFileRegex.MatchString(query) {
+		return DirectAudioFile, nil
+	}
+
 	// Assume it is a generic search if the input is not a URL
-	if u, err := url.Parse(query); err != nil || u.Scheme == "" || u.Host == "" {
+	u, err := url.Parse(query)
+	if err != nil || u.Scheme == "" || u.Host == "" {
 		return GenericSearch, nil
 	}
 
+	// Any other web URL may still be supported by one of yt-dlp's extractors
+	if u.Scheme == "http" || u.Scheme == "https" {
+		return GenericURL, nil
+	}
+
 	return "", ErrUnsupportedAudioType
 }
 
+// appleMusicTrackParam returns the track an album link points at, album links
+// shared from a single song carry it in the i query parameter.
+func appleMusicTrackParam(query string) string {
+	u, err := url.Parse(query)
+	if err != nil {
+		return ""
+	}
+
+	return u.Query().Get("i")
+}
+
+// AppleMusicTrackID returns the id of the track an Apple Music song or album link points at.
+func AppleMusicTrackID(query string) string {
+	if trackID := appleMusicTrackParam(query); trackID != "" {
+		return trackID
+	}
+
+	if match := AppleMusicRegex.FindStringSubmatch(query); match != nil && match[2] == "song" {
+		return match[3]
+	}
+
+	return ""
+}
+
+var spotifyKinds = map[string]SupportedAudioType{
+	"track":    SpotifyTrack,
+	"album":    SpotifyAlbum,
+	"playlist": SpotifyPlaylist,
+	"artist":   SpotifyArtist,
+	"show":     SpotifyShow,
+	"episode":  SpotifyEpisode,
+}
+
+// ParseSpotifyLink returns what a Spotify link or uri points at along with its id. Short
+// links are reported as SpotifyShortLink without an id, as they need to be followed first.
+func ParseSpotifyLink(query string) (SupportedAudioType, string, bool) {
+	query = strings.TrimSpace(query)
+
+	if SpotifyShortLinkRegex.MatchString(query) {
+		return SpotifyShortLink, "", true
+	}
+
+	match := SpotifyLinkRegex.FindStringSubmatch(query)
+	if match == nil {
+		match = SpotifyURIRegex.FindStringSubmatch(query)
+	}
+
+	if match == nil {
+		return "", "", false
+	}
+
+	return spotifyKinds[match[1]], match[2], true
+}
+
 // audio type is a playlist.
 func IsMultiTrackType(audioType SupportedAudioType) bool {
-	return audioType == SpotifyPlaylist || audioType == SpotifyAlbum
+	return audioType == SpotifyPlaylist || audioType == SpotifyAlbum || audioType == SpotifyArtist || audioType == SpotifyShow ||
+		audioType == YoutubePlaylist || audioType == YoutubeMix ||
+		audioType == SoundCloudPlaylist ||
+		audioType == AppleMusicAlbum || audioType == AppleMusicPlaylist ||
+		audioType == DeezerAlbum || audioType == DeezerPlaylist ||
+		audioType == TidalAlbum || audioType == TidalPlaylist
 }
 
 func IsSpotify(audioType SupportedAudioType) bool {
-	return audioType == SpotifyAlbum || audioType == SpotifyPlaylist || audioType == SpotifyTrack
+	return audioType == SpotifyAlbum || audioType == SpotifyPlaylist || audioType == SpotifyTrack ||
+		audioType == SpotifyArtist || audioType == SpotifyShow || audioType == SpotifyEpisode || audioType == SpotifyShortLink
+}
+
+func IsSoundCloud(audioType SupportedAudioType) bool {
+	return audioType == SoundCloudTrack || audioType == SoundCloudPlaylist
+}
+
+func IsAppleMusic(audioType SupportedAudioType) bool {
+	return audioType == AppleMusicTrack || audioType == AppleMusicAlbum || audioType == AppleMusicPlaylist
+}
+
+func IsDeezer(audioType SupportedAudioType) bool {
+	return audioType == DeezerTrack || audioType == DeezerAlbum || audioType == DeezerPlaylist || audioType == DeezerShortLink
+}
+
+func IsTidal(audioType SupportedAudioType) bool {
+	return audioType == TidalTrack || audioType == TidalAlbum || audioType == TidalPlaylist
 }
 
 func IsYoutube(audioType SupportedAudioType) bool {
-	return audioType == YoutubePlaylist || audioType == YoutubeSong
+	return audioType == YoutubePlaylist || audioType == YoutubeSong || audioType == YoutubeMix
+}
+
+// IsDiscordAttachment reports whether the link points at a discord attachment, these
+// links are signed and stop working after a while so they aren't worth keeping.
+func IsDiscordAttachment(query string) bool {
+	return DiscordCDNRegex.MatchString(query)
+}
+
+// YoutubeListType returns the type of the list a YouTube link carries, lists starting
+// with RD are mixes generated for the viewer, which the Data API doesn't serve.
+func YoutubeListType(query string) SupportedAudioType {
+	if match := YoutubePlaylistRegex.FindStringSubmatch(query); match != nil && strings.HasPrefix(match[1], "RD") {
+		return YoutubeMix
+	}
+
+	return YoutubePlaylist
+}
+
+// IsYoutubeVideoInList reports whether a YouTube link points at both a video and a
+// list, such as a video opened from a playlist, leaving it unclear which is wanted.
+func IsYoutubeVideoInList(query string) bool {
+	return YoutubeVideoRegex.MatchString(query) && YoutubePlaylistRegex.MatchString(query)
+}
+
+// YoutubeTimestamp returns where a YouTube link asks playback to start, given through
+// the t or start parameter as seconds or in the 1h2m3s form. It's zero when there is none.
+func YoutubeTimestamp(query string) time.Duration {
+	u, err := url.Parse(query)
+	if err != nil {
+		return 0
+	}
+
+	values := u.Query()
+	// embedded players link timestamps in the fragment
+	if fragment, err := url.ParseQuery(u.Fragment); err == nil && values.Get("t") == "" {
+		values.Set("t", fragment.Get("t"))
+	}
+
+	timestamp := values.Get("t")
+	if timestamp == "" {
+		timestamp = values.Get("start")
+	}
+
+	match := youtubeTimestampRegex.FindStringSubmatch(strings.ToLower(timestamp))
+	if timestamp == "" || match == nil {
+		return 0
+	}
+
+	var seconds int
+	for i, unit := range []int{60 * 60, 60, 1} {
+		if value, err := strconv.Atoi(match[i+1]); err == nil {
+			seconds += value * unit
+		}
+	}
+
+	return time.Duration(seconds) * time.Second
 }
 
 func FormatDuration(time time.Duration) string {
@@ -120,3 +340,30 @@ func FormatDuration(time time.Duration) string {
 	}
 	return fmt.Sprintf("%02d:%02d", int(time.Minutes()), int(time.Seconds())%60)
 }
+
+// ParseTimestamp converts a timestamp in the form of SS, MM:SS or HH:MM:SS
+// into a duration.
+func ParseTimestamp(timestamp string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(timestamp), ":")
+	if len(parts) > 3 {
+		return 0, ErrInvalidTimestamp
+	}
+
+	var total time.Duration
+
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil || value < 0 {
+			return 0, ErrInvalidTimestamp
+		}
+
+		// every unit after the leading one must fit within a minute/hour
+		if i > 0 && value >= 60 {
+			return 0, ErrInvalidTimestamp
+		}
+
+		total = total*60 + time.Duration(value)
+	}
+
+	return total * time.Second, nil
+}
@@ -0,0 +1,163 @@
+package audiotype
+
+import "testing"
+
+func TestParseSpotifyLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantType SupportedAudioType
+		wantID   string
+		wantOK   bool
+	}{
+		{
+			name:     "track link",
+			query:    "https://open.spotify.com/track/4cOdK2wGLETKBW3PvgPWqT?si=abc123",
+			wantType: SpotifyTrack,
+			wantID:   "4cOdK2wGLETKBW3PvgPWqT",
+			wantOK:   true,
+		},
+		{
+			name:     "album link over http",
+			query:    "http://open.spotify.com/album/2noRn2Aes5aoNVsU6iWThc",
+			wantType: SpotifyAlbum,
+			wantID:   "2noRn2Aes5aoNVsU6iWThc",
+			wantOK:   true,
+		},
+		{
+			name:     "playlist link with surrounding whitespace",
+			query:    "  https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M  ",
+			wantType: SpotifyPlaylist,
+			wantID:   "37i9dQZF1DXcBWIGoYBM5M",
+			wantOK:   true,
+		},
+		{
+			name:     "artist link",
+			query:    "https://open.spotify.com/artist/1Xyo4u8uXC1ZmMpatF05PJ",
+			wantType: SpotifyArtist,
+			wantID:   "1Xyo4u8uXC1ZmMpatF05PJ",
+			wantOK:   true,
+		},
+		{
+			name:     "show link",
+			query:    "https://open.spotify.com/show/2MAi0BvDc6GTFvKFPXnkCL",
+			wantType: SpotifyShow,
+			wantID:   "2MAi0BvDc6GTFvKFPXnkCL",
+			wantOK:   true,
+		},
+		{
+			name:     "episode link",
+			query:    "https://open.spotify.com/episode/512ojhOuo1ktJprKbVcKyQ",
+			wantType: SpotifyEpisode,
+			wantID:   "512ojhOuo1ktJprKbVcKyQ",
+			wantOK:   true,
+		},
+		{
+			name:     "localised link",
+			query:    "https://open.spotify.com/intl-de/track/4cOdK2wGLETKBW3PvgPWqT",
+			wantType: SpotifyTrack,
+			wantID:   "4cOdK2wGLETKBW3PvgPWqT",
+			wantOK:   true,
+		},
+		{
+			name:     "localised link with a region",
+			query:    "https://open.spotify.com/intl-pt_BR/album/2noRn2Aes5aoNVsU6iWThc",
+			wantType: SpotifyAlbum,
+			wantID:   "2noRn2Aes5aoNVsU6iWThc",
+			wantOK:   true,
+		},
+		{
+			name:     "embed link",
+			query:    "https://open.spotify.com/embed/playlist/37i9dQZF1DXcBWIGoYBM5M?utm_source=generator",
+			wantType: SpotifyPlaylist,
+			wantID:   "37i9dQZF1DXcBWIGoYBM5M",
+			wantOK:   true,
+		},
+		{
+			name:     "user playlist link",
+			query:    "https://open.spotify.com/user/spotify/playlist/37i9dQZF1DXcBWIGoYBM5M",
+			wantType: SpotifyPlaylist,
+			wantID:   "37i9dQZF1DXcBWIGoYBM5M",
+			wantOK:   true,
+		},
+		{
+			name:     "play host link",
+			query:    "https://play.spotify.com/track/4cOdK2wGLETKBW3PvgPWqT",
+			wantType: SpotifyTrack,
+			wantID:   "4cOdK2wGLETKBW3PvgPWqT",
+			wantOK:   true,
+		},
+		{
+			name:     "track uri",
+			query:    "spotify:track:4cOdK2wGLETKBW3PvgPWqT",
+			wantType: SpotifyTrack,
+			wantID:   "4cOdK2wGLETKBW3PvgPWqT",
+			wantOK:   true,
+		},
+		{
+			name:     "episode uri",
+			query:    "spotify:episode:512ojhOuo1ktJprKbVcKyQ",
+			wantType: SpotifyEpisode,
+			wantID:   "512ojhOuo1ktJprKbVcKyQ",
+			wantOK:   true,
+		},
+		{
+			name:     "user playlist uri",
+			query:    "spotify:user:spotify:playlist:37i9dQZF1DXcBWIGoYBM5M",
+			wantType: SpotifyPlaylist,
+			wantID:   "37i9dQZF1DXcBWIGoYBM5M",
+			wantOK:   true,
+		},
+		{
+			name:     "short link",
+			query:    "https://spotify.link/aBcD1234",
+			wantType: SpotifyShortLink,
+			wantOK:   true,
+		},
+		{
+			name:     "app short link",
+			query:    "https://spotify.app.link/aBcD1234?_p=xyz",
+			wantType: SpotifyShortLink,
+			wantOK:   true,
+		},
+		{name: "search text", query: "never gonna give you up"},
+		{name: "empty query", query: ""},
+		{name: "unsupported kind", query: "https://open.spotify.com/genre/pop"},
+		{name: "other host", query: "https://spotify.com.example.org/track/4cOdK2wGLETKBW3PvgPWqT"},
+		{name: "uri with trailing text", query: "spotify:track:4cOdK2wGLETKBW3PvgPWqT please"},
+		{name: "uri without an id", query: "spotify:track:"},
+		{name: "short link without a path", query: "https://spotify.link/"},
+		{name: "youtube link", query: "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audioType, id, ok := ParseSpotifyLink(tt.query)
+			if audioType != tt.wantType || id != tt.wantID || ok != tt.wantOK {
+				t.Errorf("ParseSpotifyLink(%q) = (%q, %q, %v), want (%q, %q, %v)", tt.query, audioType, id, ok, tt.wantType, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestIsDiscordAttachment(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "https://cdn.discordapp.com/attachments/1/2/song.mp3?ex=65f1&is=65de&hm=abc", want: true},
+		{query: "https://media.discordapp.net/attachments/1/2/song.ogg", want: true},
+		{query: "https://CDN.DISCORDAPP.COM/attachments/1/2/song.wav", want: true},
+		{query: "https://cdn.discordapp.com/avatars/1/avatar.png"},
+		{query: "https://example.com/attachments/song.mp3"},
+		{query: "https://cdn.discordapp.com.example.org/attachments/1/2/song.mp3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := IsDiscordAttachment(tt.query); got != tt.want {
+				t.Errorf("IsDiscordAttachment(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package deezer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+)
+
+const (
+	apiURL = "https://api.deezer.com"
+	// long playlists are cut off rather than paged through endlessly
+	maxPlaylistTracks = 500
+)
+
+type DeezerWrapper struct {
+	httpClient *http.Client
+	// baseURL is the api the wrapper talks to
+	baseURL string
+}
+
+func NewDeezerWrapper(httpClient *http.Client) *DeezerWrapper {
+	return &DeezerWrapper{
+		httpClient: httpClient,
+		baseURL:    apiURL,
+	}
+}
+
+type apiError struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type artist struct {
+	Name string `json:"name"`
+}
+
+type album struct {
+	CoverXL string `json:"cover_xl"`
+}
+
+type track struct {
+	ID       int64  `json:"id"`
+	Title    string `json:"title"`
+	Duration int64  `json:"duration"`
+	Artist   artist `json:"artist"`
+	Album    album  `json:"album"`
+}
+
+type trackList struct {
+	Data []track `json:"data"`
+	Next string  `json:"next"`
+}
+
+type collection struct {
+	Title      string    `json:"title"`
+	CoverXL    string    `json:"cover_xl"`
+	PictureXL  string    `json:"picture_xl"`
+	Tracks     trackList `json:"tracks"`
+	TrackCount int       `json:"nb_tracks"`
+}
+
+func (d *DeezerWrapper) GetTracksData(ctx context.Context, audioType audiotype.SupportedAudioType, query string) (*audiotype.Data, error) {
+	const requesterNameKey = audiotype.ContextKey("requesterName")
+
+	requesterName, ok := ctx.Value(requesterNameKey).(string)
+	if !ok {
+		return nil, errors.New("context does not have proper authorization")
+	}
+
+	if !audiotype.IsDeezer(audioType) {
+		return nil, errors.New("audio type provided is not from a deezer source")
+	}
+
+	if audioType == audiotype.DeezerShortLink {
+		resolvedURL, err := d.followShortLink(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		audioType, err = audiotype.DetermineAudioType(resolvedURL)
+		if err != nil || !audiotype.IsDeezer(audioType) || audioType == audiotype.DeezerShortLink {
+			return nil, audiotype.ErrUnsupportedAudioType
+		}
+
+		query = resolvedURL
+	}
+
+	deezerID := audiotype.DeezerRegex.FindStringSubmatch(query)[2]
+
+	switch audioType {
+	case audiotype.DeezerTrack:
+		return d.handleSingleTrack(ctx, requesterName, deezerID)
+	case audiotype.DeezerAlbum:
+		return d.handleCollection(ctx, requesterName, "album", deezerID, audiotype.DeezerAlbum)
+	default:
+		return d.handleCollection(ctx, requesterName, "playlist", deezerID, audiotype.DeezerPlaylist)
+	}
+}
+
+// followShortLink returns the url a shared link redirects to.
+func (d *DeezerWrapper) followShortLink(ctx context.Context, shortLink string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, shortLink, nil)
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("following short link: %w", err)
+	}
+
+	_ = resp.Body.Close()
+
+	return resp.Request.URL.String(), nil
+}
+
+// get requests the api url and decodes the response into result. The api reports
+// errors with a successful status, so the body is checked for them.
+func (d *DeezerWrapper) get(ctx context.Context, url string, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("requesting deezer api: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("requesting deezer api: status %d", resp.StatusCode)
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading deezer response: %w", err)
+	}
+
+	var body struct {
+		Error *apiError `json:"error"`
+	}
+
+	if err := json.Unmarshal(raw, &body); err == nil && body.Error != nil {
+		// 800 is returned for ids that don't exist
+		if body.Error.Code == 800 {
+			return audiotype.ErrSearchQueryNotFound
+		}
+
+		return fmt.Errorf("deezer api error %d: %s", body.Error.Code, body.Error.Message)
+	}
+
+	if err := json.Unmarshal(raw, result); err != nil {
+		return fmt.Errorf("decoding deezer response: %w", err)
+	}
+
+	return nil
+}
+
+func newTrackData(t track, requesterName string, imageURL string) *audiotype.TrackData {
+	trackTitle := t.Title + " - " + t.Artist.Name
+
+	if t.Album.CoverXL != "" {
+		imageURL = t.Album.CoverXL
+	}
+
+	return &audiotype.TrackData{
+		TrackName:     trackTitle,
+		TrackImageURL: imageURL,
+		Query:         "ytsearch1:" + trackTitle,
+		Requester:     requesterName,
+		Duration:      time.Duration(t.Duration) * time.Second,
+		ID:            fmt.Sprintf("deezer-%d", t.ID),
+	}
+}
+
+func (d *DeezerWrapper) handleSingleTrack(ctx context.Context, requesterName string, deezerID string) (*audiotype.Data, error) {
+	var t track
+	if err := d.get(ctx, d.baseURL+"/track/"+deezerID, &t); err != nil {
+		return nil, fmt.Errorf("getting track: %w", err)
+	}
+
+	return &audiotype.Data{
+		Tracks: []*audiotype.TrackData{newTrackData(t, requesterName, "")},
+		Type:   audiotype.DeezerTrack,
+		ID:     deezerID,
+	}, nil
+}
+
+func (d *DeezerWrapper) handleCollection(ctx context.Context, requesterName string, kind string, deezerID string, audioType audiotype.SupportedAudioType) (*audiotype.Data, error) {
+	var c collection
+	if err := d.get(ctx, d.baseURL+"/"+kind+"/"+deezerID, &c); err != nil {
+		return nil, fmt.Errorf("getting %s: %w", kind, err)
+	}
+
+	tracks := c.Tracks.Data
+
+	// the tracks embedded in a playlist are cut short, the rest are paged through
+	next := c.Tracks.Next
+	if next == "" && len(tracks) < c.TrackCount {
+		next = fmt.Sprintf("%s/%s/%s/tracks?index=%d", d.baseURL, kind, deezerID, len(tracks))
+	}
+
+	for next != "" && len(tracks) < maxPlaylistTracks {
+		var page trackList
+		if err := d.get(ctx, next, &page); err != nil {
+			return nil, fmt.Errorf("getting %s tracks: %w", kind, err)
+		}
+
+		if len(page.Data) == 0 {
+			break
+		}
+
+		tracks = append(tracks, page.Data...)
+		next = page.Next
+	}
+
+	if len(tracks) > maxPlaylistTracks {
+		tracks = tracks[:maxPlaylistTracks]
+	}
+
+	if len(tracks) == 0 {
+		return nil, audiotype.ErrSearchQueryNotFound
+	}
+
+	imageURL := c.CoverXL
+	if imageURL == "" {
+		imageURL = c.PictureXL
+	}
+
+	trackData := make([]*audiotype.TrackData, 0, len(tracks))
+	for _, t := range tracks {
+		trackData = append(trackData, newTrackData(t, requesterName, imageURL))
+	}
+
+	return &audiotype.Data{
+		Tracks: trackData,
+		Type:   audioType,
+		PlaylistData: &audiotype.PlaylistData{
+			PlaylistName:     c.Title,
+			PlaylistImageURL: imageURL,
+		},
+		ID: deezerID,
+	}, nil
+}
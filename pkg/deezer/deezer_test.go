@@ -0,0 +1,172 @@
+package deezer
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/internal/testutil"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+)
+
+func newTestWrapper() *DeezerWrapper {
+	return NewDeezerWrapper(testutil.NewFixtureClient(map[string]string{
+		"https://api.deezer.com/track/3135556":                     "track.json",
+		"https://api.deezer.com/track/404":                         "not_found.json",
+		"https://api.deezer.com/track/429":                         "quota.json",
+		"https://api.deezer.com/album/302127":                      "album.json",
+		"https://api.deezer.com/playlist/908622995":                "playlist.json",
+		"https://api.deezer.com/playlist/908622995/tracks?index=2": "playlist_tracks.json",
+		"https://deezer.page.link/harder":                          "https://www.deezer.com/en/track/3135556",
+		"https://www.deezer.com/en/track/3135556":                  "track_page.html",
+		"https://deezer.page.link/artist":                          "https://www.deezer.com/en/artist/27",
+	}))
+}
+
+func TestGetTracksData(t *testing.T) {
+	const discoveryCover = "https://e-cdns-images.dzcdn.net/images/cover/discovery/1000x1000.jpg"
+
+	harderBetter := &audiotype.TrackData{
+		TrackName:     "Harder, Better, Faster, Stronger - Daft Punk",
+		TrackImageURL: discoveryCover,
+		Query:         "ytsearch1:Harder, Better, Faster, Stronger - Daft Punk",
+		Requester:     testutil.RequesterName,
+		Duration:      224 * time.Second,
+		ID:            "deezer-3135556",
+	}
+
+	oneMoreTime := &audiotype.TrackData{
+		TrackName:     "One More Time - Daft Punk",
+		TrackImageURL: discoveryCover,
+		Query:         "ytsearch1:One More Time - Daft Punk",
+		Requester:     testutil.RequesterName,
+		Duration:      320 * time.Second,
+		ID:            "deezer-3135553",
+	}
+
+	tests := []struct {
+		name      string
+		audioType audiotype.SupportedAudioType
+		query     string
+		want      *audiotype.Data
+		wantErr   error
+	}{
+		{
+			name:      "track",
+			audioType: audiotype.DeezerTrack,
+			query:     "https://www.deezer.com/en/track/3135556",
+			want: &audiotype.Data{
+				Tracks: []*audiotype.TrackData{harderBetter},
+				Type:   audiotype.DeezerTrack,
+				ID:     "3135556",
+			},
+		},
+		{
+			name:      "album",
+			audioType: audiotype.DeezerAlbum,
+			query:     "https://www.deezer.com/album/302127",
+			want: &audiotype.Data{
+				Tracks: []*audiotype.TrackData{oneMoreTime, harderBetter},
+				Type:   audiotype.DeezerAlbum,
+				PlaylistData: &audiotype.PlaylistData{
+					PlaylistName:     "Discovery",
+					PlaylistImageURL: discoveryCover,
+				},
+				ID: "302127",
+			},
+		},
+		{
+			name:      "playlist paged past its embedded tracks",
+			audioType: audiotype.DeezerPlaylist,
+			query:     "https://www.deezer.com/fr/playlist/908622995",
+			want: &audiotype.Data{
+				Tracks: []*audiotype.TrackData{
+					oneMoreTime,
+					{
+						TrackName:     "Around the World - Daft Punk",
+						TrackImageURL: "https://e-cdns-images.dzcdn.net/images/playlist/electro/1000x1000.jpg",
+						Query:         "ytsearch1:Around the World - Daft Punk",
+						Requester:     testutil.RequesterName,
+						Duration:      429 * time.Second,
+						ID:            "deezer-1109731",
+					},
+					{
+						TrackName:     "Windowlicker - Aphex Twin",
+						TrackImageURL: "https://e-cdns-images.dzcdn.net/images/cover/windowlicker/1000x1000.jpg",
+						Query:         "ytsearch1:Windowlicker - Aphex Twin",
+						Requester:     testutil.RequesterName,
+						Duration:      367 * time.Second,
+						ID:            "deezer-916424",
+					},
+				},
+				Type: audiotype.DeezerPlaylist,
+				PlaylistData: &audiotype.PlaylistData{
+					PlaylistName:     "Electro Classics",
+					PlaylistImageURL: "https://e-cdns-images.dzcdn.net/images/playlist/electro/1000x1000.jpg",
+				},
+				ID: "908622995",
+			},
+		},
+		{
+			name:      "short link",
+			audioType: audiotype.DeezerShortLink,
+			query:     "https://deezer.page.link/harder",
+			want: &audiotype.Data{
+				Tracks: []*audiotype.TrackData{harderBetter},
+				Type:   audiotype.DeezerTrack,
+				ID:     "3135556",
+			},
+		},
+		{
+			name:      "short link to an unsupported page",
+			audioType: audiotype.DeezerShortLink,
+			query:     "https://deezer.page.link/artist",
+			wantErr:   audiotype.ErrUnsupportedAudioType,
+		},
+		{
+			name:      "missing track",
+			audioType: audiotype.DeezerTrack,
+			query:     "https://www.deezer.com/track/404",
+			wantErr:   audiotype.ErrSearchQueryNotFound,
+		},
+	}
+
+	wrapper := newTestWrapper()
+	ctx := testutil.RequesterContext()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wrapper.GetTracksData(ctx, tt.audioType, tt.query)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetTracksData() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GetTracksData() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTracksData() = %s, want %s", testutil.DescribeData(got), testutil.DescribeData(tt.want))
+			}
+		})
+	}
+}
+
+func TestGetTracksDataAPIError(t *testing.T) {
+	ctx := testutil.RequesterContext()
+
+	_, err := newTestWrapper().GetTracksData(ctx, audiotype.DeezerTrack, "https://www.deezer.com/track/429")
+	if err == nil || errors.Is(err, audiotype.ErrSearchQueryNotFound) {
+		t.Fatalf("GetTracksData() error = %v, want the api error", err)
+	}
+
+	if !strings.Contains(err.Error(), "Quota limit exceeded") {
+		t.Errorf("GetTracksData() error = %v, want it to carry the api message", err)
+	}
+}
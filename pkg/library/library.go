@@ -0,0 +1,353 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiofile"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// number of files probed at once while scanning
+	probeConcurrency = 4
+	probeTimeout     = 30 * time.Second
+)
+
+var audioExtensions = map[string]struct{}{
+	".mp3":  {},
+	".flac": {},
+	".ogg":  {},
+	".opus": {},
+	".wav":  {},
+	".m4a":  {},
+	".aac":  {},
+}
+
+type Track struct {
+	// Path is relative to the library directory and identifies the track
+	Path     string
+	Title    string
+	Artist   string
+	Album    string
+	Duration time.Duration
+	modTime  time.Time
+	size     int64
+	// searchText holds the lowercased fields a search is matched against
+	searchText string
+}
+
+// Name returns how the track is displayed, falling back to its file name when untagged.
+func (t *Track) Name() string {
+	if t.Artist == "" {
+		return t.Title
+	}
+
+	return t.Title + " - " + t.Artist
+}
+
+// Library is a searchable index of the audio files in a directory, kept up to date by rescanning it.
+type Library struct {
+	dir    string
+	mu     sync.RWMutex
+	tracks map[string]*Track
+}
+
+func New(dir string) (*Library, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving library directory: %w", err)
+	}
+
+	return &Library{
+		dir:    absDir,
+		tracks: make(map[string]*Track),
+	}, nil
+}
+
+// Scan brings the index in line with the directory, only files that are new or
+// have changed since the previous scan are probed.
+func (l *Library) Scan(ctx context.Context) error {
+	found := make(map[string]fs.FileInfo)
+
+	err := filepath.WalkDir(l.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		if _, ok := audioExtensions[strings.ToLower(filepath.Ext(path))]; !ok {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			// the file was removed while walking
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+
+		relPath, err := filepath.Rel(l.dir, path)
+		if err != nil {
+			return err
+		}
+
+		found[filepath.ToSlash(relPath)] = info
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("walking library directory: %w", err)
+	}
+
+	l.mu.RLock()
+	changed := []string{}
+	for relPath, info := range found {
+		track, ok := l.tracks[relPath]
+		if !ok || !track.modTime.Equal(info.ModTime()) || track.size != info.Size() {
+			changed = append(changed, relPath)
+		}
+	}
+	l.mu.RUnlock()
+
+	probed := make([]*Track, len(changed))
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(probeConcurrency)
+
+	for i, relPath := range changed {
+		group.Go(func() error {
+			track, err := l.probe(groupCtx, relPath, found[relPath])
+			if err != nil {
+				if groupCtx.Err() != nil {
+					return groupCtx.Err()
+				}
+
+				// files that aren't readable audio are left out of the index
+				return nil
+			}
+
+			probed[i] = track
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return fmt.Errorf("probing library files: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for relPath := range l.tracks {
+		if _, ok := found[relPath]; !ok {
+			delete(l.tracks, relPath)
+		}
+	}
+
+	for i, relPath := range changed {
+		if probed[i] == nil {
+			delete(l.tracks, relPath)
+			continue
+		}
+
+		l.tracks[relPath] = probed[i]
+	}
+
+	return nil
+}
+
+func (l *Library) probe(ctx context.Context, relPath string, info fs.FileInfo) (*Track, error) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	metadata, err := audiofile.Probe(ctx, l.AbsPath(relPath))
+	if err != nil {
+		return nil, err
+	}
+
+	track := &Track{
+		Path:     relPath,
+		Title:    metadata.Title,
+		Artist:   metadata.Artist,
+		Album:    metadata.Album,
+		Duration: metadata.Duration,
+		modTime:  info.ModTime(),
+		size:     info.Size(),
+	}
+
+	fileName := strings.TrimSuffix(filepath.Base(relPath), filepath.Ext(relPath))
+	if track.Title == "" {
+		track.Title = fileName
+	}
+
+	track.searchText = strings.ToLower(strings.Join([]string{track.Title, track.Artist, track.Album, fileName}, " "))
+
+	return track, nil
+}
+
+// Watch rescans the directory on an interval until the context is done, so added,
+// changed and removed files are picked up. Scan errors are passed to onError.
+func (l *Library) Watch(ctx context.Context, interval time.Duration, onError func(err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.Scan(ctx); err != nil && ctx.Err() == nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// AbsPath returns where the track with the relative path is stored.
+func (l *Library) AbsPath(relPath string) string {
+	return filepath.Join(l.dir, filepath.FromSlash(relPath))
+}
+
+func (l *Library) Get(relPath string) (*Track, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	track, ok := l.tracks[relPath]
+
+	return track, ok
+}
+
+func (l *Library) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return len(l.tracks)
+}
+
+// Search returns up to limit tracks containing every word of the query, tracks
+// whose title matches the query rank first.
+func (l *Library) Search(query string, limit int) []*Track {
+	query = strings.ToLower(strings.TrimSpace(query))
+	words := strings.Fields(query)
+
+	type result struct {
+		track *Track
+		score int
+	}
+
+	l.mu.RLock()
+	results := []result{}
+
+	for _, track := range l.tracks {
+		matchesAll := true
+		for _, word := range words {
+			if !strings.Contains(track.searchText, word) {
+				matchesAll = false
+				break
+			}
+		}
+
+		if !matchesAll {
+			continue
+		}
+
+		title := strings.ToLower(track.Title)
+		score := 0
+
+		switch {
+		case query == "":
+		case title == query:
+			score = 3
+		case strings.HasPrefix(title, query):
+			score = 2
+		case strings.Contains(title, query):
+			score = 1
+		}
+
+		results = append(results, result{track: track, score: score})
+	}
+	l.mu.RUnlock()
+
+	slices.SortFunc(results, func(a, b result) int {
+		if a.score != b.score {
+			return b.score - a.score
+		}
+
+		return strings.Compare(a.track.Path, b.track.Path)
+	})
+
+	tracks := make([]*Track, 0, min(limit, len(results)))
+	for _, result := range results[:min(limit, len(results))] {
+		tracks = append(tracks, result.track)
+	}
+
+	return tracks
+}
+
+// Wrapper retrieves tracks from the library for queries prefixed with "library:".
+type Wrapper struct {
+	library *Library
+}
+
+func NewWrapper(library *Library) *Wrapper {
+	return &Wrapper{
+		library: library,
+	}
+}
+
+func (w *Wrapper) GetTracksData(ctx context.Context, audioType audiotype.SupportedAudioType, query string) (*audiotype.Data, error) {
+	const requesterNameKey = audiotype.ContextKey("requesterName")
+
+	requesterName, ok := ctx.Value(requesterNameKey).(string)
+	if !ok {
+		return nil, errors.New("context does not have proper authorization")
+	}
+
+	if audioType != audiotype.LibraryTrack {
+		return nil, errors.New("audio type provided is not a library track")
+	}
+
+	query = strings.TrimSpace(query[len(audiotype.LibraryPrefix):])
+
+	// autocomplete suggestions refer to tracks by their path
+	track, ok := w.library.Get(query)
+	if !ok {
+		results := w.library.Search(query, 1)
+		if len(results) == 0 {
+			return nil, audiotype.ErrSearchQueryNotFound
+		}
+
+		track = results[0]
+	}
+
+	return &audiotype.Data{
+		Tracks: []*audiotype.TrackData{
+			{
+				TrackName: track.Name(),
+				Query:     w.library.AbsPath(track.Path),
+				Requester: requesterName,
+				Duration:  track.Duration,
+				ID:        track.Path,
+				AudioType: audiotype.LibraryTrack,
+			},
+		},
+		Type: audiotype.LibraryTrack,
+		ID:   track.Path,
+	}, nil
+}
@@ -0,0 +1,211 @@
+package pagemeta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// pages are only read up to this size, the metadata sits near the top
+	maxPageSize = 4 * 1024 * 1024
+	userAgent   = "Mozilla/5.0 (compatible; spice-tunes)"
+)
+
+var (
+	metaTagRegex     = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	attributeRegex   = regexp.MustCompile(`(?is)([a-z:_-]+)\s*=\s*("([^"]*)"|'([^']*)')`)
+	linkedDataRegex  = regexp.MustCompile(`(?is)<script[^>]*type\s*=\s*["']application/ld\+json["'][^>]*>(.*?)</script>`)
+	isoDurationRegex = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+)
+
+// Page holds the metadata music services embed in their pages for link previews.
+type Page struct {
+	// Meta maps the property or name of each meta tag to its content, the first tag wins
+	Meta map[string]string
+	// LinkedData holds the documents of the page's ld+json scripts
+	LinkedData []json.RawMessage
+	// URL is where the page was served from, after redirects
+	URL string
+}
+
+// Fetch downloads the page and extracts its metadata.
+func Fetch(ctx context.Context, httpClient *http.Client, pageURL string) (*Page, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting page: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("requesting page: status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading page: %w", err)
+	}
+
+	page := Parse(string(body))
+	page.URL = resp.Request.URL.String()
+
+	return page, nil
+}
+
+// Parse extracts the meta tags and ld+json documents of an html page.
+func Parse(document string) *Page {
+	page := &Page{
+		Meta: make(map[string]string),
+	}
+
+	for _, tag := range metaTagRegex.FindAllString(document, -1) {
+		attributes := map[string]string{}
+		for _, match := range attributeRegex.FindAllStringSubmatch(tag, -1) {
+			attributes[strings.ToLower(match[1])] = html.UnescapeString(match[3] + match[4])
+		}
+
+		key := attributes["property"]
+		if key == "" {
+			key = attributes["name"]
+		}
+
+		if _, exists := page.Meta[key]; key != "" && !exists {
+			page.Meta[key] = strings.TrimSpace(attributes["content"])
+		}
+	}
+
+	for _, match := range linkedDataRegex.FindAllStringSubmatch(document, -1) {
+		data := json.RawMessage(strings.TrimSpace(match[1]))
+		if json.Valid(data) {
+			page.LinkedData = append(page.LinkedData, data)
+		}
+	}
+
+	return page
+}
+
+// Recording is a track as described by schema.org ld+json.
+type Recording struct {
+	Name     string          `json:"name"`
+	Duration string          `json:"duration"`
+	URL      string          `json:"url"`
+	ByArtist json.RawMessage `json:"byArtist"`
+}
+
+// Artist returns the name of the first artist credited, which may be given as an object or a list of them.
+func (r Recording) Artist() string {
+	return firstName(r.ByArtist)
+}
+
+// Collection is an album or playlist as described by schema.org ld+json.
+type Collection struct {
+	Type     string          `json:"@type"`
+	Name     string          `json:"name"`
+	ByArtist json.RawMessage `json:"byArtist"`
+	Image    json.RawMessage `json:"image"`
+	Tracks   []Recording     `json:"track"`
+}
+
+func (c Collection) Artist() string {
+	return firstName(c.ByArtist)
+}
+
+// ImageURL returns the collection's artwork, which may be given as a url, an object or a list of either.
+func (c Collection) ImageURL() string {
+	var url string
+	if err := json.Unmarshal(c.Image, &url); err == nil {
+		return url
+	}
+
+	type image struct {
+		URL string `json:"url"`
+	}
+
+	var single image
+	if err := json.Unmarshal(c.Image, &single); err == nil {
+		return single.URL
+	}
+
+	var urls []string
+	if err := json.Unmarshal(c.Image, &urls); err == nil && len(urls) > 0 {
+		return urls[0]
+	}
+
+	return ""
+}
+
+// Collection returns the first album or playlist described by the page's ld+json.
+func (p *Page) Collection() (*Collection, bool) {
+	for _, data := range p.LinkedData {
+		var collection Collection
+		if err := json.Unmarshal(data, &collection); err != nil {
+			continue
+		}
+
+		if collection.Type == "MusicAlbum" || collection.Type == "MusicPlaylist" {
+			return &collection, true
+		}
+	}
+
+	return nil, false
+}
+
+func firstName(data json.RawMessage) string {
+	type named struct {
+		Name string `json:"name"`
+	}
+
+	var single named
+	if err := json.Unmarshal(data, &single); err == nil {
+		return single.Name
+	}
+
+	var list []named
+	if err := json.Unmarshal(data, &list); err == nil && len(list) > 0 {
+		return list[0].Name
+	}
+
+	return ""
+}
+
+// ParseISODuration parses durations such as PT3M42S, returning zero for anything else.
+func ParseISODuration(duration string) time.Duration {
+	match := isoDurationRegex.FindStringSubmatch(strings.TrimSpace(duration))
+	if match == nil {
+		return 0
+	}
+
+	var total float64
+
+	for i, unit := range []float64{24 * 60 * 60, 60 * 60, 60, 1} {
+		if match[i+1] == "" {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(match[i+1], 64)
+		if err != nil {
+			return 0
+		}
+
+		total += value * unit
+	}
+
+	return time.Duration(total * float64(time.Second))
+}
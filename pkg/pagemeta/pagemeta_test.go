@@ -0,0 +1,163 @@
+package pagemeta
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+const albumPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta property="og:title" content="Discovery by Daft Punk">
+<meta property="og:title" content="ignored duplicate">
+<meta name="og:description" content="  Listen to Discovery &amp; more  ">
+<meta content='https://example.com/discovery.jpg' property='og:image'>
+<meta charset="utf-8">
+<script type="application/ld+json">{ not json }</script>
+<script type="application/ld+json">{"@type": "WebSite", "name": "Example"}</script>
+<script type='application/ld+json'>
+{
+  "@type": "MusicAlbum",
+  "name": "Discovery",
+  "byArtist": {"@type": "MusicGroup", "name": "Daft Punk"},
+  "image": {"@type": "ImageObject", "url": "https://example.com/discovery-large.jpg"},
+  "track": [
+    {"name": "One More Time", "duration": "PT5M20S", "url": "https://example.com/track/1"},
+    {"name": "Digital Love", "duration": "PT4M58S", "byArtist": [{"name": "Daft Punk"}, {"name": "Someone"}]}
+  ]
+}
+</script>
+</head>
+</html>`
+
+func TestParse(t *testing.T) {
+	page := Parse(albumPage)
+
+	wantMeta := map[string]string{
+		"og:title":       "Discovery by Daft Punk",
+		"og:description": "Listen to Discovery & more",
+		"og:image":       "https://example.com/discovery.jpg",
+	}
+	if !reflect.DeepEqual(page.Meta, wantMeta) {
+		t.Errorf("Parse() meta = %v, want %v", page.Meta, wantMeta)
+	}
+
+	if len(page.LinkedData) != 2 {
+		t.Fatalf("Parse() kept %d ld+json documents, want 2", len(page.LinkedData))
+	}
+
+	collection, ok := page.Collection()
+	if !ok {
+		t.Fatal("Collection() found no album")
+	}
+
+	if collection.Type != "MusicAlbum" || collection.Name != "Discovery" || collection.Artist() != "Daft Punk" {
+		t.Errorf("Collection() = {%q, %q, %q}, want {MusicAlbum, Discovery, Daft Punk}", collection.Type, collection.Name, collection.Artist())
+	}
+
+	if got := collection.ImageURL(); got != "https://example.com/discovery-large.jpg" {
+		t.Errorf("ImageURL() = %q", got)
+	}
+
+	if len(collection.Tracks) != 2 {
+		t.Fatalf("Collection() has %d tracks, want 2", len(collection.Tracks))
+	}
+
+	first, second := collection.Tracks[0], collection.Tracks[1]
+	if first.Name != "One More Time" || first.Duration != "PT5M20S" || first.URL != "https://example.com/track/1" || first.Artist() != "" {
+		t.Errorf("first track = %+v", first)
+	}
+
+	if second.Name != "Digital Love" || second.Artist() != "Daft Punk" {
+		t.Errorf("second track = {%q, %q}, want {Digital Love, Daft Punk}", second.Name, second.Artist())
+	}
+}
+
+func TestCollection(t *testing.T) {
+	tests := []struct {
+		name      string
+		document  string
+		wantFound bool
+		wantName  string
+		wantImage string
+	}{
+		{
+			name:      "playlist with an image url",
+			document:  `<script type="application/ld+json">{"@type": "MusicPlaylist", "name": "Mix", "image": "https://example.com/mix.jpg"}</script>`,
+			wantFound: true,
+			wantName:  "Mix",
+			wantImage: "https://example.com/mix.jpg",
+		},
+		{
+			name:      "playlist with a list of image urls",
+			document:  `<script type="application/ld+json">{"@type": "MusicPlaylist", "name": "Mix", "image": ["https://example.com/1.jpg", "https://example.com/2.jpg"]}</script>`,
+			wantFound: true,
+			wantName:  "Mix",
+			wantImage: "https://example.com/1.jpg",
+		},
+		{
+			name:      "album without an image",
+			document:  `<script type="application/ld+json">{"@type": "MusicAlbum", "name": "Bare"}</script>`,
+			wantFound: true,
+			wantName:  "Bare",
+		},
+		{
+			name:     "recording only",
+			document: `<script type="application/ld+json">{"@type": "MusicRecording", "name": "Single"}</script>`,
+		},
+		{
+			name:     "list of documents",
+			document: `<script type="application/ld+json">[{"@type": "MusicAlbum", "name": "Nested"}]</script>`,
+		},
+		{
+			name:     "no linked data",
+			document: `<html><head><title>Nothing</title></head></html>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collection, ok := Parse(tt.document).Collection()
+			if ok != tt.wantFound {
+				t.Fatalf("Collection() found = %v, want %v", ok, tt.wantFound)
+			}
+
+			if !ok {
+				return
+			}
+
+			if collection.Name != tt.wantName || collection.ImageURL() != tt.wantImage {
+				t.Errorf("Collection() = {%q, %q}, want {%q, %q}", collection.Name, collection.ImageURL(), tt.wantName, tt.wantImage)
+			}
+		})
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		duration string
+		want     time.Duration
+	}{
+		{duration: "PT3M42S", want: 3*time.Minute + 42*time.Second},
+		{duration: "PT1H2M3S", want: time.Hour + 2*time.Minute + 3*time.Second},
+		{duration: "PT45S", want: 45 * time.Second},
+		{duration: "PT2H", want: 2 * time.Hour},
+		{duration: "PT1.5S", want: 1500 * time.Millisecond},
+		{duration: "P1DT1M", want: 24*time.Hour + time.Minute},
+		{duration: " PT10M ", want: 10 * time.Minute},
+		{duration: "P0D", want: 0},
+		{duration: "", want: 0},
+		{duration: "3:42", want: 0},
+		{duration: "PT3M42", want: 0},
+		{duration: "pt3m42s", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.duration, func(t *testing.T) {
+			if got := ParseISODuration(tt.duration); got != tt.want {
+				t.Errorf("ParseISODuration(%q) = %v, want %v", tt.duration, got, tt.want)
+			}
+		})
+	}
+}
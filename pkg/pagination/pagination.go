@@ -9,13 +9,18 @@ import (
 // It takes in paginated data, the current page number, and a separator (the number of items per page).
 type GetPaginationEmbed[T any] func(data []*T, pageNum int, totalPages int, separator int) *discordgo.MessageEmbed
 
+// GetPageComponents is a function type used for adding components, such as a select menu, for the items of a page.
+// It takes in the page's data, the current page number, and the separator, and returns the rows to show above the pagination buttons.
+type GetPageComponents[T any] func(data []*T, pageNum int, separator int) []discordgo.MessageComponent
+
 // PaginationConfig is a generic struct that manages the configuration for paginating a set of data.
 // The Data field holds the full dataset, while the Separator specifies how many items should appear per page.
 type PaginationConfig[T any] struct {
-	Data       []*T // Slice of items to paginate
-	Separator  int  // Number of items to display per page
-	pageNum    *int // Pointer to the current page number
-	totalPages *int // Pointer to the total number of pages
+	Data           []*T                 // Slice of items to paginate
+	Separator      int                  // Number of items to display per page
+	pageNum        *int                 // Pointer to the current page number
+	totalPages     *int                 // Pointer to the total number of pages
+	pageComponents GetPageComponents[T] // Optional components added for the items of the current page
 }
 
 // NewPaginatedConfig creates a new PaginationConfig for handling paginated views.
@@ -32,6 +37,12 @@ func NewPaginatedConfig[T any](data []*T, separator int) *PaginationConfig[T] {
 	}
 }
 
+// SetPageComponents registers a function providing extra components for the items of the current page,
+// which are shown above the pagination buttons and rebuilt whenever the page changes.
+func (p *PaginationConfig[T]) SetPageComponents(pageComponents GetPageComponents[T]) {
+	p.pageComponents = pageComponents
+}
+
 func (p *PaginationConfig[T]) UpdateData(data []*T, separator int) {
 	p.Data = data
 	p.Separator = separator
@@ -163,6 +174,12 @@ func (p *PaginationConfig[T]) GetViewConfig(paginationEmbedRetriever GetPaginati
 		*p.pageNum = 1
 	}
 
+	// Place any page specific components, such as a select menu, above the pagination buttons.
+	if p.pageComponents != nil {
+		pageComponents := p.pageComponents(pages[*p.pageNum-1], *p.pageNum, p.Separator)
+		paginationButtons = append(pageComponents, paginationButtons...)
+	}
+
 	// Return a new ViewConfig with the current page's embed and the corresponding buttons.
 	return &views.Config{
 		Components: &views.ComponentHandler{
@@ -0,0 +1,285 @@
+package podcast
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	itunesNamespace = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+	// feeds of long running shows can get large, but nothing close to this
+	maxFeedSize = 32 * 1024 * 1024
+)
+
+var (
+	ErrNotFeed    = errors.New("document is not an rss or atom feed")
+	ErrNoEpisodes = errors.New("feed does not contain any audio episodes")
+)
+
+type Episode struct {
+	// GUID identifies the episode within its feed, falling back to the audio url
+	GUID      string
+	Title     string
+	AudioURL  string
+	ImageURL  string
+	Duration  time.Duration
+	Published time.Time
+}
+
+type Feed struct {
+	Title    string
+	Author   string
+	ImageURL string
+	// Episodes are kept in the order of the feed, which is usually newest first
+	Episodes []*Episode
+}
+
+// rss fields without a namespace also match namespaced elements of the same name,
+// so the itunes variants are declared first to claim their elements.
+type rssFeed struct {
+	Channel struct {
+		ItunesAuthor string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd author"`
+		ItunesImage  struct {
+			Href string `xml:"href,attr"`
+		} `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+		ItunesTitle string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd title"`
+		Title       string `xml:"title"`
+		Image       struct {
+			URL string `xml:"url"`
+		} `xml:"image"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type rssItem struct {
+	ItunesTitle    string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd title"`
+	ItunesDuration string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+	ItunesImage    struct {
+		Href string `xml:"href,attr"`
+	} `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+	Title     string `xml:"title"`
+	GUID      string `xml:"guid"`
+	PubDate   string `xml:"pubDate"`
+	Enclosure struct {
+		URL  string `xml:"url,attr"`
+		Type string `xml:"type,attr"`
+	} `xml:"enclosure"`
+}
+
+type atomFeed struct {
+	Title  string `xml:"title"`
+	Logo   string `xml:"logo"`
+	Icon   string `xml:"icon"`
+	Author struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	Links     []atomLink `xml:"link"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr"`
+	Href string `xml:"href,attr"`
+	Type string `xml:"type,attr"`
+}
+
+// Fetch downloads and parses the feed at the url.
+func Fetch(ctx context.Context, httpClient *http.Client, feedURL string) (*Feed, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting feed: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d", ErrNotFeed, resp.StatusCode)
+	}
+
+	return Parse(io.LimitReader(resp.Body, maxFeedSize))
+}
+
+// Parse reads an rss or atom feed, episodes without an audio enclosure are left out.
+func Parse(r io.Reader) (*Feed, error) {
+	decoder := xml.NewDecoder(r)
+	// feeds regularly declare legacy encodings while only using ascii
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, ErrNotFeed
+			}
+
+			return nil, fmt.Errorf("reading feed: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		var feed *Feed
+
+		switch start.Name.Local {
+		case "rss":
+			var rss rssFeed
+			if err := decoder.DecodeElement(&rss, &start); err != nil {
+				return nil, fmt.Errorf("decoding rss feed: %w", err)
+			}
+
+			feed = rss.toFeed()
+		case "feed":
+			var atom atomFeed
+			if err := decoder.DecodeElement(&atom, &start); err != nil {
+				return nil, fmt.Errorf("decoding atom feed: %w", err)
+			}
+
+			feed = atom.toFeed()
+		default:
+			return nil, ErrNotFeed
+		}
+
+		if len(feed.Episodes) == 0 {
+			return nil, ErrNoEpisodes
+		}
+
+		return feed, nil
+	}
+}
+
+func (r *rssFeed) toFeed() *Feed {
+	channel := r.Channel
+
+	feed := &Feed{
+		Title:    firstNonEmpty(channel.Title, channel.ItunesTitle),
+		Author:   strings.TrimSpace(channel.ItunesAuthor),
+		ImageURL: firstNonEmpty(channel.ItunesImage.Href, channel.Image.URL),
+	}
+
+	for _, item := range channel.Items {
+		if item.Enclosure.URL == "" || !isAudio(item.Enclosure.Type) {
+			continue
+		}
+
+		feed.Episodes = append(feed.Episodes, &Episode{
+			GUID:      firstNonEmpty(item.GUID, item.Enclosure.URL),
+			Title:     firstNonEmpty(item.Title, item.ItunesTitle, "Untitled episode"),
+			AudioURL:  strings.TrimSpace(item.Enclosure.URL),
+			ImageURL:  firstNonEmpty(item.ItunesImage.Href, feed.ImageURL),
+			Duration:  parseDuration(item.ItunesDuration),
+			Published: parseDate(item.PubDate),
+		})
+	}
+
+	return feed
+}
+
+func (a *atomFeed) toFeed() *Feed {
+	feed := &Feed{
+		Title:    strings.TrimSpace(a.Title),
+		Author:   strings.TrimSpace(a.Author.Name),
+		ImageURL: firstNonEmpty(a.Logo, a.Icon),
+	}
+
+	for _, entry := range a.Entries {
+		for _, link := range entry.Links {
+			if link.Rel != "enclosure" || link.Href == "" || !isAudio(link.Type) {
+				continue
+			}
+
+			feed.Episodes = append(feed.Episodes, &Episode{
+				GUID:      firstNonEmpty(entry.ID, link.Href),
+				Title:     firstNonEmpty(entry.Title, "Untitled episode"),
+				AudioURL:  strings.TrimSpace(link.Href),
+				ImageURL:  feed.ImageURL,
+				Published: parseDate(firstNonEmpty(entry.Published, entry.Updated)),
+			})
+
+			break
+		}
+	}
+
+	return feed
+}
+
+// isAudio reports whether an enclosure holds audio, enclosures without a type are assumed to.
+func isAudio(mimeType string) bool {
+	return mimeType == "" || strings.HasPrefix(strings.ToLower(mimeType), "audio/")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// parseDuration reads itunes durations, given either in seconds or as [hh:]mm:ss.
+func parseDuration(duration string) time.Duration {
+	duration = strings.TrimSpace(duration)
+	if duration == "" {
+		return 0
+	}
+
+	var seconds float64
+
+	for _, part := range strings.Split(duration, ":") {
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil || value < 0 {
+			return 0
+		}
+
+		seconds = seconds*60 + value
+	}
+
+	return time.Duration(seconds * float64(time.Second))
+}
+
+var dateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+}
+
+func parseDate(date string) time.Time {
+	date = strings.TrimSpace(date)
+
+	for _, layout := range dateLayouts {
+		if published, err := time.Parse(layout, date); err == nil {
+			return published
+		}
+	}
+
+	return time.Time{}
+}
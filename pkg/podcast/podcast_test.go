@@ -0,0 +1,190 @@
+package podcast
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func parseFixture(t *testing.T, name string) (*Feed, error) {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("opening fixture: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = file.Close()
+	})
+
+	return Parse(file)
+}
+
+func TestParseRSS(t *testing.T) {
+	feed, err := parseFixture(t, "rss.xml")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := &Feed{
+		Title:    "Spice Radio Hour",
+		Author:   "The Spice Crew",
+		ImageURL: "https://example.com/show.jpg",
+		Episodes: []*Episode{
+			{
+				GUID:      "spice-radio-3",
+				Title:     "Episode 3: Bass Lines",
+				AudioURL:  "https://example.com/episode-3.mp3",
+				ImageURL:  "https://example.com/episode-3.jpg",
+				Duration:  time.Hour + 2*time.Minute + 3*time.Second,
+				Published: time.Date(2024, time.September, 3, 10, 0, 0, 0, time.UTC),
+			},
+			{
+				GUID:      "https://example.com/episode-1.m4a",
+				Title:     "Untyped Enclosure",
+				AudioURL:  "https://example.com/episode-1.m4a",
+				ImageURL:  "https://example.com/show.jpg",
+				Duration:  45*time.Minute + 30*time.Second,
+				Published: time.Date(2024, time.August, 20, 10, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	assertFeed(t, feed, want)
+}
+
+func TestParseAtom(t *testing.T) {
+	feed, err := parseFixture(t, "atom.xml")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := &Feed{
+		Title:    "Atom Beats",
+		Author:   "Atom Author",
+		ImageURL: "https://example.com/icon.png",
+		Episodes: []*Episode{
+			{
+				GUID:      "urn:uuid:atom-2",
+				Title:     "Second Drop",
+				AudioURL:  "https://example.com/atom-2.ogg",
+				ImageURL:  "https://example.com/icon.png",
+				Published: time.Date(2024, time.September, 1, 12, 30, 0, 0, time.UTC),
+			},
+			{
+				GUID:      "https://example.com/atom-1.mp3",
+				Title:     "First Drop",
+				AudioURL:  "https://example.com/atom-1.mp3",
+				ImageURL:  "https://example.com/icon.png",
+				Published: time.Date(2024, time.August, 1, 6, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	assertFeed(t, feed, want)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		wantErr error
+	}{
+		{name: "feed without audio", fixture: "no_episodes.xml", wantErr: ErrNoEpisodes},
+		{name: "html page", fixture: "not_feed.html", wantErr: ErrNotFeed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseFixture(t, tt.fixture); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := Parse(strings.NewReader("")); !errors.Is(err, ErrNotFeed) {
+		t.Errorf("Parse() of an empty document error = %v, want %v", err, ErrNotFeed)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		duration string
+		want     time.Duration
+	}{
+		{duration: "01:02:03", want: time.Hour + 2*time.Minute + 3*time.Second},
+		{duration: "1:00:00", want: time.Hour},
+		{duration: "45:30", want: 45*time.Minute + 30*time.Second},
+		{duration: "05:07", want: 5*time.Minute + 7*time.Second},
+		{duration: "2730", want: 45*time.Minute + 30*time.Second},
+		{duration: " 90 ", want: 90 * time.Second},
+		{duration: "12.5", want: 12500 * time.Millisecond},
+		{duration: "", want: 0},
+		{duration: "unknown", want: 0},
+		{duration: "10:-5", want: 0},
+		{duration: "1::2", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.duration, func(t *testing.T) {
+			if got := parseDuration(tt.duration); got != tt.want {
+				t.Errorf("parseDuration(%q) = %v, want %v", tt.duration, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		date string
+		want time.Time
+	}{
+		{date: "Tue, 03 Sep 2024 10:00:00 +0000", want: time.Date(2024, time.September, 3, 10, 0, 0, 0, time.UTC)},
+		{date: "Tue, 3 Sep 2024 12:00:00 +0200", want: time.Date(2024, time.September, 3, 10, 0, 0, 0, time.UTC)},
+		{date: "Tue, 03 Sep 2024 10:00:00 GMT", want: time.Date(2024, time.September, 3, 10, 0, 0, 0, time.UTC)},
+		{date: "3 Sep 2024 10:00:00 +0000", want: time.Date(2024, time.September, 3, 10, 0, 0, 0, time.UTC)},
+		{date: "2024-09-03T10:00:00Z", want: time.Date(2024, time.September, 3, 10, 0, 0, 0, time.UTC)},
+		{date: " 2024-09-03T12:00:00+02:00 ", want: time.Date(2024, time.September, 3, 10, 0, 0, 0, time.UTC)},
+		{date: "", want: time.Time{}},
+		{date: "last tuesday", want: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			if got := parseDate(tt.date); !got.Equal(tt.want) {
+				t.Errorf("parseDate(%q) = %v, want %v", tt.date, got, tt.want)
+			}
+		})
+	}
+}
+
+func assertFeed(t *testing.T, got *Feed, want *Feed) {
+	t.Helper()
+
+	if got.Title != want.Title || got.Author != want.Author || got.ImageURL != want.ImageURL {
+		t.Errorf("feed = {%q, %q, %q}, want {%q, %q, %q}", got.Title, got.Author, got.ImageURL, want.Title, want.Author, want.ImageURL)
+	}
+
+	if len(got.Episodes) != len(want.Episodes) {
+		t.Fatalf("got %d episodes, want %d", len(got.Episodes), len(want.Episodes))
+	}
+
+	for i, episode := range got.Episodes {
+		wantEpisode := want.Episodes[i]
+		if !episode.Published.Equal(wantEpisode.Published) {
+			t.Errorf("episode %d published = %v, want %v", i, episode.Published, wantEpisode.Published)
+		}
+
+		gotEpisode, expectedEpisode := *episode, *wantEpisode
+		gotEpisode.Published, expectedEpisode.Published = time.Time{}, time.Time{}
+
+		if !reflect.DeepEqual(gotEpisode, expectedEpisode) {
+			t.Errorf("episode %d = %+v, want %+v", i, gotEpisode, expectedEpisode)
+		}
+	}
+}
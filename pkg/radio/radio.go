@@ -0,0 +1,290 @@
+package radio
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// playlists are tiny, anything bigger is not worth parsing
+	maxPlaylistSize = 64 * 1024
+	// playlists may point at other playlists, but not endlessly
+	maxPlaylistDepth = 3
+)
+
+var (
+	ErrNotAudioStream = errors.New("url does not serve an audio stream")
+	// ErrHLSStream is returned by Open for HLS streams, which ffmpeg has to read by itself.
+	ErrHLSStream = errors.New("stream is an HLS playlist")
+)
+
+// streamClient has no timeout as live streams are read indefinitely,
+// requests are bounded by their context instead.
+var streamClient = &http.Client{}
+
+type Station struct {
+	Name  string
+	Genre string
+	// URL of the stream itself, after resolving any playlists
+	URL string
+}
+
+type streamKind int
+
+const (
+	audioStream streamKind = iota
+	hlsStream
+	playlistFile
+	unknownContent
+)
+
+func newStreamRequest(ctx context.Context, streamURL string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, streamURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	// asks icecast and shoutcast servers to interleave the stream metadata
+	req.Header.Set("Icy-MetaData", "1")
+
+	return req, nil
+}
+
+func contentKind(resp *http.Response) streamKind {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	path := strings.ToLower(resp.Request.URL.Path)
+
+	switch {
+	case strings.Contains(mediaType, "mpegurl") || strings.HasSuffix(path, ".m3u8") || strings.HasSuffix(path, ".m3u"):
+		if strings.HasSuffix(path, ".m3u8") || mediaType == "application/vnd.apple.mpegurl" {
+			return hlsStream
+		}
+
+		return playlistFile
+	case strings.Contains(mediaType, "scpls") || strings.HasSuffix(path, ".pls"):
+		return playlistFile
+	case strings.HasPrefix(mediaType, "audio/") || mediaType == "application/ogg" || resp.Header.Get("icy-metaint") != "":
+		return audioStream
+	}
+
+	return unknownContent
+}
+
+// Resolve checks that the url serves a live audio stream, following .pls and .m3u
+// playlists to the stream they point at.
+func Resolve(ctx context.Context, streamURL string) (*Station, error) {
+	return resolve(ctx, streamURL, 0)
+}
+
+func resolve(ctx context.Context, streamURL string, depth int) (*Station, error) {
+	req, err := newStreamRequest(ctx, streamURL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting stream: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d", ErrNotAudioStream, resp.StatusCode)
+	}
+
+	station := &Station{
+		Name:  resp.Header.Get("icy-name"),
+		Genre: resp.Header.Get("icy-genre"),
+		URL:   streamURL,
+	}
+
+	if station.Name == "" {
+		station.Name = resp.Request.URL.Host
+	}
+
+	switch contentKind(resp) {
+	case audioStream, hlsStream:
+		return station, nil
+
+	case playlistFile:
+		if depth >= maxPlaylistDepth {
+			return nil, ErrNotAudioStream
+		}
+
+		entryURL, err := firstPlaylistEntry(io.LimitReader(resp.Body, maxPlaylistSize), resp.Request.URL)
+		if err != nil {
+			return nil, err
+		}
+
+		return resolve(ctx, entryURL, depth+1)
+	}
+
+	return nil, ErrNotAudioStream
+}
+
+// firstPlaylistEntry returns the first stream listed in a .pls or .m3u playlist.
+func firstPlaylistEntry(playlist io.Reader, base *url.URL) (string, error) {
+	scanner := bufio.NewScanner(playlist)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// pls entries look like File1=http://...
+		if key, value, ok := strings.Cut(line, "="); ok && strings.HasPrefix(strings.ToLower(key), "file") {
+			line = strings.TrimSpace(value)
+		}
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") {
+			continue
+		}
+
+		entryURL, err := base.Parse(line)
+		if err != nil || (entryURL.Scheme != "http" && entryURL.Scheme != "https") {
+			continue
+		}
+
+		return entryURL.String(), nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("reading playlist: %w", err)
+	}
+
+	return "", ErrNotAudioStream
+}
+
+// Stream reads a live audio stream with the ICY metadata stripped out,
+// reporting stream title changes as they are read.
+type Stream struct {
+	body      io.ReadCloser
+	metaInt   int
+	remaining int
+	title     string
+	onTitle   func(title string)
+}
+
+// Open connects to the stream, onTitle is called from Read whenever the
+// station announces a new title so it should return quickly.
+func Open(ctx context.Context, streamURL string, onTitle func(title string)) (*Stream, error) {
+	req, err := newStreamRequest(ctx, streamURL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting stream: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w: status %d", ErrNotAudioStream, resp.StatusCode)
+	}
+
+	if contentKind(resp) == hlsStream {
+		_ = resp.Body.Close()
+		return nil, ErrHLSStream
+	}
+
+	stream := &Stream{
+		body:    resp.Body,
+		onTitle: onTitle,
+	}
+
+	if metaInt := resp.Header.Get("icy-metaint"); metaInt != "" {
+		interval, err := strconv.Atoi(metaInt)
+		if err != nil || interval < 0 {
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("parsing icy-metaint %q: %w", metaInt, ErrNotAudioStream)
+		}
+
+		stream.metaInt = interval
+		stream.remaining = interval
+	}
+
+	return stream, nil
+}
+
+func (s *Stream) Read(p []byte) (int, error) {
+	if s.metaInt == 0 {
+		return s.body.Read(p)
+	}
+
+	if s.remaining == 0 {
+		if err := s.readMetadata(); err != nil {
+			return 0, err
+		}
+
+		s.remaining = s.metaInt
+	}
+
+	if len(p) > s.remaining {
+		p = p[:s.remaining]
+	}
+
+	n, err := s.body.Read(p)
+	s.remaining -= n
+
+	return n, err
+}
+
+// readMetadata consumes a metadata block, its length is given in 16 byte
+// units by the first byte.
+func (s *Stream) readMetadata() error {
+	var length [1]byte
+	if _, err := io.ReadFull(s.body, length[:]); err != nil {
+		return err
+	}
+
+	if length[0] == 0 {
+		return nil
+	}
+
+	metadata := make([]byte, int(length[0])*16)
+	if _, err := io.ReadFull(s.body, metadata); err != nil {
+		return err
+	}
+
+	title := parseStreamTitle(strings.TrimRight(string(metadata), "\x00"))
+	if title != s.title {
+		s.title = title
+
+		if s.onTitle != nil {
+			s.onTitle(title)
+		}
+	}
+
+	return nil
+}
+
+func (s *Stream) Close() error {
+	return s.body.Close()
+}
+
+// parseStreamTitle extracts the title from metadata such as "StreamTitle='Artist - Song';".
+func parseStreamTitle(metadata string) string {
+	const titleKey = "StreamTitle='"
+
+	start := strings.Index(metadata, titleKey)
+	if start == -1 {
+		return ""
+	}
+
+	title := metadata[start+len(titleKey):]
+	if end := strings.Index(title, "';"); end != -1 {
+		return title[:end]
+	}
+
+	return strings.TrimSuffix(title, "'")
+}
@@ -0,0 +1,76 @@
+package soundcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/ytdlp"
+	"github.com/wader/goutubedl"
+)
+
+type SoundCloudWrapper struct {
+	httpClient *http.Client
+}
+
+func NewSoundCloudWrapper(httpClient *http.Client) *SoundCloudWrapper {
+	return &SoundCloudWrapper{
+		httpClient: httpClient,
+	}
+}
+
+func (s *SoundCloudWrapper) GetTracksData(ctx context.Context, audioType audiotype.SupportedAudioType, query string) (*audiotype.Data, error) {
+	const requesterNameKey = audiotype.ContextKey("requesterName")
+
+	requesterName, ok := ctx.Value(requesterNameKey).(string)
+	if !ok {
+		return nil, errors.New("context does not have proper authorization")
+	}
+
+	switch audioType {
+	case audiotype.SoundCloudTrack:
+		return s.handleSingleTrack(ctx, requesterName, query)
+	case audiotype.SoundCloudPlaylist:
+		return s.handleSet(ctx, requesterName, query)
+	}
+
+	return nil, errors.New("audio type provided is not from a soundcloud source")
+}
+
+func (s *SoundCloudWrapper) handleSingleTrack(ctx context.Context, requesterName string, query string) (*audiotype.Data, error) {
+	info, err := ytdlp.Extract(ctx, s.httpClient, query, goutubedl.TypeSingle)
+	if err != nil {
+		return nil, fmt.Errorf("getting track metadata: %w", err)
+	}
+
+	return &audiotype.Data{
+		Tracks: []*audiotype.TrackData{ytdlp.NewTrackData(info, requesterName)},
+		Type:   audiotype.SoundCloudTrack,
+		ID:     info.ID,
+	}, nil
+}
+
+func (s *SoundCloudWrapper) handleSet(ctx context.Context, requesterName string, query string) (*audiotype.Data, error) {
+	info, err := ytdlp.Extract(ctx, s.httpClient, query, goutubedl.TypePlaylist)
+	if err != nil {
+		return nil, fmt.Errorf("getting set metadata: %w", err)
+	}
+
+	if len(info.Entries) == 0 {
+		return nil, audiotype.ErrSearchQueryNotFound
+	}
+
+	trackData := make([]*audiotype.TrackData, 0, len(info.Entries))
+	for _, entry := range info.Entries {
+		trackData = append(trackData, ytdlp.NewTrackData(entry, requesterName))
+	}
+
+	return &audiotype.Data{
+		Tracks:       trackData,
+		Type:         audiotype.SoundCloudPlaylist,
+		PlaylistData: ytdlp.NewPlaylistData(info, trackData),
+		ID:           info.ID,
+	}, nil
+}
@@ -2,22 +2,40 @@ package spotify
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"sync"
+	"time"
 
 	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
 	"github.com/TeddyKahwaji/spice-tunes-go/pkg/funcs"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/pagemeta"
 	"github.com/zmb3/spotify"
 )
 
+const (
+	apiURL = "https://api.spotify.com/v1/"
+	// catalog lookups made without a user need a market to tell what's available
+	market = "US"
+	// the latest episodes queued for a show link
+	maxShowEpisodes = 10
+)
+
 type SpotifyClientWrapper struct {
 	client *spotify.Client
+	// tokenClient authorizes requests to the api the client doesn't cover
+	tokenClient *http.Client
+	// httpClient follows short links, which mustn't be sent the api token
+	httpClient *http.Client
 }
 
-func NewSpotifyClientWrapper(client *spotify.Client) *SpotifyClientWrapper {
+func NewSpotifyClientWrapper(client *spotify.Client, tokenClient *http.Client, httpClient *http.Client) *SpotifyClientWrapper {
 	return &SpotifyClientWrapper{
-		client: client,
+		client:      client,
+		tokenClient: tokenClient,
+		httpClient:  httpClient,
 	}
 }
 
@@ -109,6 +127,53 @@ func (s *SpotifyClientWrapper) SearchTrack(query string) (*audiotype.Data, error
 	return audioData, nil
 }
 
+// SearchTracks returns up to limit tracks matching the query, in the order Spotify ranks them.
+func (s *SpotifyClientWrapper) SearchTracks(ctx context.Context, query string, limit int) ([]*audiotype.TrackData, error) {
+	const requesterNameKey = audiotype.ContextKey("requesterName")
+
+	requesterName, ok := ctx.Value(requesterNameKey).(string)
+	if !ok {
+		return nil, errors.New("context does not have proper authorization")
+	}
+
+	country := market
+	result, err := s.client.SearchOpt(query, spotify.SearchTypeTrack, &spotify.Options{
+		Limit:   &limit,
+		Country: &country,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("searching tracks: %w", err)
+	}
+
+	if result.Tracks == nil || len(result.Tracks.Tracks) == 0 {
+		return nil, audiotype.ErrSearchQueryNotFound
+	}
+
+	tracks := make([]*audiotype.TrackData, 0, len(result.Tracks.Tracks))
+	for _, track := range result.Tracks.Tracks {
+		trackTitle := track.Name
+		if len(track.Artists) > 0 {
+			trackTitle += " - " + track.Artists[0].Name
+		}
+
+		trackData := &audiotype.TrackData{
+			ID:        track.ID.String(),
+			TrackName: trackTitle,
+			Query:     "ytsearch1:" + trackTitle,
+			Requester: requesterName,
+			Duration:  track.TimeDuration(),
+		}
+
+		if len(track.Album.Images) > 0 {
+			trackData.TrackImageURL = track.Album.Images[0].URL
+		}
+
+		tracks = append(tracks, trackData)
+	}
+
+	return tracks, nil
+}
+
 func (s *SpotifyClientWrapper) GetTracksData(ctx context.Context, audioType audiotype.SupportedAudioType, query string) (*audiotype.Data, error) {
 	var (
 		result *audiotype.Data
@@ -122,13 +187,20 @@ func (s *SpotifyClientWrapper) GetTracksData(ctx context.Context, audioType audi
 		return nil, errors.New("context does not have proper authorization")
 	}
 
-	if audioType != audiotype.SpotifyPlaylist && audioType != audiotype.SpotifyTrack && audioType != audiotype.SpotifyAlbum {
+	if !audiotype.IsSpotify(audioType) {
 		return nil, errors.New("audio type provided is not from a spotify source")
 	}
 
-	spotifyTrackID, err := extractSpotifyID(audioType, query)
-	if err != nil {
-		return nil, fmt.Errorf("extracting playlistID: %w", err)
+	if audioType == audiotype.SpotifyShortLink {
+		query, err = s.followShortLink(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	audioType, spotifyTrackID, ok := audiotype.ParseSpotifyLink(query)
+	if !ok || audioType == audiotype.SpotifyShortLink {
+		return nil, audiotype.ErrUnsupportedAudioType
 	}
 
 	switch audioType {
@@ -140,6 +212,15 @@ func (s *SpotifyClientWrapper) GetTracksData(ctx context.Context, audioType audi
 
 	case audiotype.SpotifyTrack:
 		result, err = s.handleSingleTrackData(requesterName, spotifyTrackID)
+
+	case audiotype.SpotifyArtist:
+		result, err = s.handleArtistData(requesterName, spotifyTrackID)
+
+	case audiotype.SpotifyShow:
+		result, err = s.handleShowData(requesterName, spotifyTrackID)
+
+	case audiotype.SpotifyEpisode:
+		result, err = s.handleEpisodeData(ctx, requesterName, spotifyTrackID)
 	}
 
 	if err != nil {
@@ -343,26 +424,155 @@ func (s *SpotifyClientWrapper) handlePlaylistData(requesterName string, spotifyT
 	}, nil
 }
 
-func extractSpotifyID(audioType audiotype.SupportedAudioType, spotifyURL string) (string, error) {
-	switch audioType {
-	case audiotype.SpotifyPlaylist:
-		matches := audiotype.SpotifyPlaylistRegex.FindStringSubmatch(spotifyURL)
-		if len(matches) > 1 {
-			return matches[1], nil
+// followShortLink returns the open.spotify.com link a spotify.link share link redirects
+// to. Browsers are sometimes sent a page redirecting through javascript instead, in
+// which case the link is read from the page's metadata.
+func (s *SpotifyClientWrapper) followShortLink(ctx context.Context, shortLink string) (string, error) {
+	page, err := pagemeta.Fetch(ctx, s.httpClient, shortLink)
+	if err != nil {
+		return "", fmt.Errorf("following short link: %w", err)
+	}
+
+	for _, link := range []string{page.URL, page.Meta["og:url"], page.Meta["al:web:url"]} {
+		if audioType, _, ok := audiotype.ParseSpotifyLink(link); ok && audioType != audiotype.SpotifyShortLink {
+			return link, nil
 		}
+	}
 
-	case audiotype.SpotifyAlbum:
-		matches := audiotype.SpotifyAlbumRegex.FindStringSubmatch(spotifyURL)
-		if len(matches) > 1 {
-			return matches[1], nil
+	return "", audiotype.ErrSearchQueryNotFound
+}
+
+func (s *SpotifyClientWrapper) handleArtistData(requesterName string, artistID string) (*audiotype.Data, error) {
+	artist, err := s.client.GetArtist(spotify.ID(artistID))
+	if err != nil {
+		return nil, fmt.Errorf("getting artist: %w", err)
+	}
+
+	tracks, err := s.client.GetArtistsTopTracks(spotify.ID(artistID), market)
+	if err != nil {
+		return nil, fmt.Errorf("getting artist top tracks: %w", err)
+	}
+
+	playlistData := &audiotype.PlaylistData{
+		PlaylistName: artist.Name + " Top Tracks",
+	}
+
+	if len(artist.Images) > 0 {
+		playlistData.PlaylistImageURL = artist.Images[0].URL
+	}
+
+	trackData := make([]*audiotype.TrackData, 0, len(tracks))
+	for _, track := range tracks {
+		trackTitle := track.Name + " - " + track.Artists[0].Name
+		data := &audiotype.TrackData{
+			TrackName: trackTitle,
+			ID:        track.ID.String(),
+			Query:     "ytsearch1:" + trackTitle,
+			Requester: requesterName,
+			Duration:  track.TimeDuration(),
 		}
 
-	case audiotype.SpotifyTrack:
-		matches := audiotype.SpotifyTrackRegex.FindStringSubmatch(spotifyURL)
-		if len(matches) > 1 {
-			return matches[1], nil
+		if len(track.Album.Images) > 0 {
+			data.TrackImageURL = track.Album.Images[0].URL
 		}
+
+		trackData = append(trackData, data)
+	}
+
+	return &audiotype.Data{
+		Tracks:       trackData,
+		Type:         audiotype.SpotifyArtist,
+		PlaylistData: playlistData,
+		ID:           artistID,
+	}, nil
+}
+
+// newEpisodeTrackData searches for an episode by its name and show, as episodes are
+// often uploaded to YouTube as well.
+func newEpisodeTrackData(episode *spotify.EpisodePage, showName string, requesterName string) *audiotype.TrackData {
+	trackTitle := episode.Name + " - " + showName
+	trackData := &audiotype.TrackData{
+		TrackName: trackTitle,
+		ID:        episode.ID.String(),
+		Query:     "ytsearch1:" + trackTitle,
+		Requester: requesterName,
+		Duration:  time.Duration(episode.Duration_ms) * time.Millisecond,
+	}
+
+	if len(episode.Images) > 0 {
+		trackData.TrackImageURL = episode.Images[0].URL
+	}
+
+	return trackData
+}
+
+func (s *SpotifyClientWrapper) handleShowData(requesterName string, showID string) (*audiotype.Data, error) {
+	country := market
+	limit := maxShowEpisodes
+
+	show, err := s.client.GetShowOpt(&spotify.Options{Country: &country}, showID)
+	if err != nil {
+		return nil, fmt.Errorf("getting show: %w", err)
+	}
+
+	episodes, err := s.client.GetShowEpisodesOpt(&spotify.Options{Country: &country, Limit: &limit}, showID)
+	if err != nil {
+		return nil, fmt.Errorf("getting show episodes: %w", err)
+	}
+
+	playlistData := &audiotype.PlaylistData{
+		PlaylistName: show.Name,
+	}
+
+	if len(show.Images) > 0 {
+		playlistData.PlaylistImageURL = show.Images[0].URL
+	}
+
+	trackData := make([]*audiotype.TrackData, 0, len(episodes.Episodes))
+	for _, episode := range episodes.Episodes {
+		trackData = append(trackData, newEpisodeTrackData(&episode, show.Name, requesterName))
+	}
+
+	return &audiotype.Data{
+		Tracks:       trackData,
+		Type:         audiotype.SpotifyShow,
+		PlaylistData: playlistData,
+		ID:           showID,
+	}, nil
+}
+
+// handleEpisodeData requests the episode from the api directly, as the client predates episodes.
+func (s *SpotifyClientWrapper) handleEpisodeData(ctx context.Context, requesterName string, episodeID string) (*audiotype.Data, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"episodes/"+episodeID+"?market="+market, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := s.tokenClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("getting episode: %w", err)
 	}
 
-	return "", errors.New("error could not extract any ID")
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, audiotype.ErrSearchQueryNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("getting episode: status %d", resp.StatusCode)
+	}
+
+	var episode spotify.EpisodePage
+	if err := json.NewDecoder(resp.Body).Decode(&episode); err != nil {
+		return nil, fmt.Errorf("decoding episode: %w", err)
+	}
+
+	return &audiotype.Data{
+		Tracks: []*audiotype.TrackData{newEpisodeTrackData(&episode, episode.Show.Name, requesterName)},
+		Type:   audiotype.SpotifyEpisode,
+		ID:     episodeID,
+	}, nil
 }
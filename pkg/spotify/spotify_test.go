@@ -0,0 +1,69 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/internal/testutil"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+)
+
+func TestFollowShortLink(t *testing.T) {
+	tests := []struct {
+		name      string
+		shortLink string
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "redirect",
+			shortLink: "https://spotify.link/redirect",
+			want:      "https://open.spotify.com/track/4cOdK2wGLETKBW3PvgPWqT",
+		},
+		{
+			name:      "page linking through og:url",
+			shortLink: "https://spotify.link/ogurl",
+			want:      "https://open.spotify.com/album/2noRn2Aes5aoNVsU6iWThc",
+		},
+		{
+			name:      "page linking through al:web:url",
+			shortLink: "https://spotify.link/applink",
+			want:      "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M?si=abc123",
+		},
+		{
+			name:      "page without a spotify link",
+			shortLink: "https://spotify.link/unknown",
+			wantErr:   audiotype.ErrSearchQueryNotFound,
+		},
+	}
+
+	wrapper := NewSpotifyClientWrapper(nil, nil, testutil.NewFixtureClient(map[string]string{
+		"https://spotify.link/redirect":                         "https://open.spotify.com/track/4cOdK2wGLETKBW3PvgPWqT",
+		"https://open.spotify.com/track/4cOdK2wGLETKBW3PvgPWqT": "track.html",
+		"https://spotify.link/ogurl":                            "shortlink_og_url.html",
+		"https://spotify.link/applink":                          "shortlink_app_link.html",
+		"https://spotify.link/unknown":                          "shortlink_unknown.html",
+	}))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wrapper.followShortLink(context.Background(), tt.shortLink)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("followShortLink() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("followShortLink() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("followShortLink() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
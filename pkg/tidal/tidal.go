@@ -0,0 +1,154 @@
+package tidal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/pagemeta"
+)
+
+const browseURL = "https://tidal.com/browse"
+
+// TidalWrapper reads tracks from the metadata of Tidal's public pages, as its api
+// requires credentials. Albums and playlists only resolve when their page lists the tracks.
+type TidalWrapper struct {
+	httpClient *http.Client
+}
+
+func NewTidalWrapper(httpClient *http.Client) *TidalWrapper {
+	return &TidalWrapper{
+		httpClient: httpClient,
+	}
+}
+
+func (t *TidalWrapper) GetTracksData(ctx context.Context, audioType audiotype.SupportedAudioType, query string) (*audiotype.Data, error) {
+	const requesterNameKey = audiotype.ContextKey("requesterName")
+
+	requesterName, ok := ctx.Value(requesterNameKey).(string)
+	if !ok {
+		return nil, errors.New("context does not have proper authorization")
+	}
+
+	match := audiotype.TidalRegex.FindStringSubmatch(query)
+	if match == nil || !audiotype.IsTidal(audioType) {
+		return nil, errors.New("audio type provided is not from a tidal source")
+	}
+
+	kind, tidalID := match[1], match[2]
+
+	page, err := pagemeta.Fetch(ctx, t.httpClient, browseURL+"/"+kind+"/"+tidalID)
+	if err != nil {
+		return nil, fmt.Errorf("getting %s page: %w", kind, err)
+	}
+
+	if audioType == audiotype.TidalTrack {
+		return handleSingleTrack(page, requesterName, tidalID)
+	}
+
+	return handleCollection(page, requesterName, audioType, tidalID)
+}
+
+func handleSingleTrack(page *pagemeta.Page, requesterName string, tidalID string) (*audiotype.Data, error) {
+	title, artist := splitTitle(page.Meta["og:title"])
+	if artist == "" {
+		_, artist = splitTitle(page.Meta["og:description"])
+	}
+
+	if title == "" {
+		return nil, audiotype.ErrSearchQueryNotFound
+	}
+
+	trackTitle := title
+	if artist != "" {
+		trackTitle += " - " + artist
+	}
+
+	trackData := &audiotype.TrackData{
+		TrackName:     trackTitle,
+		TrackImageURL: page.Meta["og:image"],
+		Query:         "ytsearch1:" + trackTitle,
+		Requester:     requesterName,
+		ID:            "tidal-" + tidalID,
+	}
+
+	if seconds, err := strconv.Atoi(page.Meta["music:duration"]); err == nil {
+		trackData.Duration = time.Duration(seconds) * time.Second
+	}
+
+	return &audiotype.Data{
+		Tracks: []*audiotype.TrackData{trackData},
+		Type:   audiotype.TidalTrack,
+		ID:     tidalID,
+	}, nil
+}
+
+func handleCollection(page *pagemeta.Page, requesterName string, audioType audiotype.SupportedAudioType, tidalID string) (*audiotype.Data, error) {
+	collection, ok := page.Collection()
+	if !ok || len(collection.Tracks) == 0 {
+		return nil, audiotype.ErrSearchQueryNotFound
+	}
+
+	imageURL := collection.ImageURL()
+	if imageURL == "" {
+		imageURL = page.Meta["og:image"]
+	}
+
+	trackData := make([]*audiotype.TrackData, 0, len(collection.Tracks))
+
+	for _, recording := range collection.Tracks {
+		trackTitle := recording.Name
+
+		artist := recording.Artist()
+		if artist == "" && audioType == audiotype.TidalAlbum {
+			artist = collection.Artist()
+		}
+
+		if artist != "" {
+			trackTitle += " - " + artist
+		}
+
+		track := &audiotype.TrackData{
+			TrackName:     trackTitle,
+			TrackImageURL: imageURL,
+			Query:         "ytsearch1:" + trackTitle,
+			Requester:     requesterName,
+			Duration:      pagemeta.ParseISODuration(recording.Duration),
+		}
+
+		if match := audiotype.TidalRegex.FindStringSubmatch(recording.URL); match != nil && match[1] == "track" {
+			track.ID = "tidal-" + match[2]
+		}
+
+		trackData = append(trackData, track)
+	}
+
+	return &audiotype.Data{
+		Tracks: trackData,
+		Type:   audioType,
+		PlaylistData: &audiotype.PlaylistData{
+			PlaylistName:     collection.Name,
+			PlaylistImageURL: imageURL,
+		},
+		ID: tidalID,
+	}, nil
+}
+
+// splitTitle separates a preview title such as "Song by Artist on TIDAL" into its parts,
+// the artist is empty when the title doesn't name one.
+func splitTitle(title string) (string, string) {
+	title = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(title), " on TIDAL"))
+	title = strings.TrimPrefix(title, "Listen to ")
+
+	index := strings.LastIndex(title, " by ")
+	if index == -1 {
+		return title, ""
+	}
+
+	return strings.TrimSpace(title[:index]), strings.TrimSpace(title[index+len(" by "):])
+}
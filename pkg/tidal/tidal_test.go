@@ -0,0 +1,176 @@
+package tidal
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/internal/testutil"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+)
+
+func TestGetTracksData(t *testing.T) {
+	tests := []struct {
+		name      string
+		audioType audiotype.SupportedAudioType
+		query     string
+		want      *audiotype.Data
+		wantErr   error
+	}{
+		{
+			name:      "track",
+			audioType: audiotype.TidalTrack,
+			query:     "https://tidal.com/browse/track/77640617",
+			want: &audiotype.Data{
+				Tracks: []*audiotype.TrackData{
+					{
+						TrackName:     "Blinding Lights - The Weeknd",
+						TrackImageURL: "https://resources.tidal.com/images/after-hours/1280x1280.jpg",
+						Query:         "ytsearch1:Blinding Lights - The Weeknd",
+						Requester:     testutil.RequesterName,
+						Duration:      200 * time.Second,
+						ID:            "tidal-77640617",
+					},
+				},
+				Type: audiotype.TidalTrack,
+				ID:   "77640617",
+			},
+		},
+		{
+			name:      "track crediting its artist in the description",
+			audioType: audiotype.TidalTrack,
+			query:     "https://listen.tidal.com/track/1109731",
+			want: &audiotype.Data{
+				Tracks: []*audiotype.TrackData{
+					{
+						TrackName: "Around the World - Daft Punk & Friends",
+						Query:     "ytsearch1:Around the World - Daft Punk & Friends",
+						Requester: testutil.RequesterName,
+						ID:        "tidal-1109731",
+					},
+				},
+				Type: audiotype.TidalTrack,
+				ID:   "1109731",
+			},
+		},
+		{
+			name:      "album",
+			audioType: audiotype.TidalAlbum,
+			query:     "https://listen.tidal.com/album/1541482",
+			want: &audiotype.Data{
+				Tracks: []*audiotype.TrackData{
+					{
+						TrackName:     "One More Time - Daft Punk",
+						TrackImageURL: "https://resources.tidal.com/images/discovery/1280x1280.jpg",
+						Query:         "ytsearch1:One More Time - Daft Punk",
+						Requester:     testutil.RequesterName,
+						Duration:      5*time.Minute + 20*time.Second,
+						ID:            "tidal-1541483",
+					},
+					{
+						TrackName:     "Digital Love - Daft Punk feat. Someone",
+						TrackImageURL: "https://resources.tidal.com/images/discovery/1280x1280.jpg",
+						Query:         "ytsearch1:Digital Love - Daft Punk feat. Someone",
+						Requester:     testutil.RequesterName,
+						Duration:      4*time.Minute + 58*time.Second,
+						ID:            "tidal-1541485",
+					},
+				},
+				Type: audiotype.TidalAlbum,
+				PlaylistData: &audiotype.PlaylistData{
+					PlaylistName:     "Discovery",
+					PlaylistImageURL: "https://resources.tidal.com/images/discovery/1280x1280.jpg",
+				},
+				ID: "1541482",
+			},
+		},
+		{
+			name:      "playlist",
+			audioType: audiotype.TidalPlaylist,
+			query:     "https://tidal.com/playlist/3f1b4b1e-late-night",
+			want: &audiotype.Data{
+				Tracks: []*audiotype.TrackData{
+					{
+						TrackName:     "Windowlicker - Aphex Twin",
+						TrackImageURL: "https://resources.tidal.com/images/late-night/1080x1080.jpg",
+						Query:         "ytsearch1:Windowlicker - Aphex Twin",
+						Requester:     testutil.RequesterName,
+						Duration:      6*time.Minute + 7*time.Second,
+						ID:            "tidal-916424",
+					},
+					{
+						TrackName:     "Untitled Interlude",
+						TrackImageURL: "https://resources.tidal.com/images/late-night/1080x1080.jpg",
+						Query:         "ytsearch1:Untitled Interlude",
+						Requester:     testutil.RequesterName,
+					},
+				},
+				Type: audiotype.TidalPlaylist,
+				PlaylistData: &audiotype.PlaylistData{
+					PlaylistName:     "Late Night Electronic",
+					PlaylistImageURL: "https://resources.tidal.com/images/late-night/1080x1080.jpg",
+				},
+				ID: "3f1b4b1e-late-night",
+			},
+		},
+		{
+			name:      "playlist page without tracks",
+			audioType: audiotype.TidalPlaylist,
+			query:     "https://tidal.com/browse/playlist/private",
+			wantErr:   audiotype.ErrSearchQueryNotFound,
+		},
+	}
+
+	wrapper := NewTidalWrapper(testutil.NewFixtureClient(map[string]string{
+		"https://tidal.com/browse/track/77640617":               "track.html",
+		"https://tidal.com/browse/track/1109731":                "track_description.html",
+		"https://tidal.com/browse/album/1541482":                "album.html",
+		"https://tidal.com/browse/playlist/3f1b4b1e-late-night": "playlist.html",
+		"https://tidal.com/browse/playlist/private":             "playlist_empty.html",
+	}))
+	ctx := testutil.RequesterContext()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wrapper.GetTracksData(ctx, tt.audioType, tt.query)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetTracksData() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GetTracksData() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTracksData() = %s, want %s", testutil.DescribeData(got), testutil.DescribeData(tt.want))
+			}
+		})
+	}
+}
+
+func TestSplitTitle(t *testing.T) {
+	tests := []struct {
+		title      string
+		wantTitle  string
+		wantArtist string
+	}{
+		{title: "Blinding Lights by The Weeknd on TIDAL", wantTitle: "Blinding Lights", wantArtist: "The Weeknd"},
+		{title: "Listen to Stand by Me by Ben E. King on TIDAL", wantTitle: "Stand by Me", wantArtist: "Ben E. King"},
+		{title: "Late Night Electronic on TIDAL", wantTitle: "Late Night Electronic"},
+		{title: "  Untitled  ", wantTitle: "Untitled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			title, artist := splitTitle(tt.title)
+			if title != tt.wantTitle || artist != tt.wantArtist {
+				t.Errorf("splitTitle() = (%q, %q), want (%q, %q)", title, artist, tt.wantTitle, tt.wantArtist)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package trackmatch
+
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	durationWeight = 40.0
+	titleWeight    = 30.0
+	artistWeight   = 20.0
+	// candidates further off than this get no duration score at all
+	durationTolerance = 30 * time.Second
+	unwantedPenalty   = 25.0
+	topicChannelBonus = 5.0
+	officialBonus     = 3.0
+	videoPenalty      = 3.0
+)
+
+// unwantedTerms mark versions of a song that are rarely what was asked for,
+// they are only penalized when the target itself doesn't mention them.
+var unwantedTerms = []string{
+	"live",
+	"cover",
+	"sped up",
+	"speed up",
+	"slowed",
+	"reverb",
+	"reaction",
+	"karaoke",
+	"instrumental",
+	"nightcore",
+	"8d",
+	"remix",
+	"acoustic",
+}
+
+// videoTerms mark music videos, which tend to have intros or skits on top of the song.
+var videoTerms = []string{
+	"official video",
+	"music video",
+}
+
+// Target is the track being looked for.
+type Target struct {
+	Title    string
+	Artist   string
+	Duration time.Duration
+}
+
+// Candidate is a search result that may be the target.
+type Candidate struct {
+	Title    string
+	Channel  string
+	Duration time.Duration
+}
+
+// ParseTrackName splits a track name in the form of "Title - Artist" into its parts,
+// when there is no separator the whole name is treated as the title.
+func ParseTrackName(name string) (string, string) {
+	separator := strings.LastIndex(name, " - ")
+	if separator == -1 {
+		return name, ""
+	}
+
+	return name[:separator], name[separator+3:]
+}
+
+// Score rates how likely the candidate is the recording described by the target,
+// a higher score is a better match.
+func Score(target Target, candidate Candidate) float64 {
+	candidateTitle := normalize(candidate.Title)
+	candidateText := candidateTitle + " " + normalize(candidate.Channel)
+	targetText := normalize(target.Title + " " + target.Artist)
+
+	score := titleWeight * coverage(tokenize(target.Title), candidateTitle)
+
+	if target.Artist != "" {
+		score += artistWeight * artistScore(target.Artist, candidateText)
+	}
+
+	if target.Duration > 0 && candidate.Duration > 0 {
+		difference := (target.Duration - candidate.Duration).Abs()
+		score += durationWeight * math.Max(0, 1-difference.Seconds()/durationTolerance.Seconds())
+	}
+
+	for _, term := range unwantedTerms {
+		if containsPhrase(candidateTitle, term) && !containsPhrase(targetText, term) {
+			score -= unwantedPenalty
+		}
+	}
+
+	for _, term := range videoTerms {
+		if containsPhrase(candidateTitle, term) {
+			score -= videoPenalty
+			break
+		}
+	}
+
+	if strings.HasSuffix(normalize(candidate.Channel), " topic") {
+		score += topicChannelBonus
+	}
+
+	if containsPhrase(candidateTitle, "official audio") {
+		score += officialBonus
+	}
+
+	return score
+}
+
+// Best returns the index of the candidate that best matches the target,
+// or -1 when there are no candidates.
+func Best(target Target, candidates []Candidate) int {
+	best := -1
+	bestScore := math.Inf(-1)
+
+	for i, candidate := range candidates {
+		if score := Score(target, candidate); score > bestScore {
+			best = i
+			bestScore = score
+		}
+	}
+
+	return best
+}
+
+// artistScore matches the artist against the candidate title and channel, ignoring
+// spaces so channels such as "ArtistNameVEVO" still match.
+func artistScore(artist string, candidateText string) float64 {
+	compactArtist := strings.ReplaceAll(normalize(artist), " ", "")
+	if compactArtist != "" && strings.Contains(strings.ReplaceAll(candidateText, " ", ""), compactArtist) {
+		return 1
+	}
+
+	return coverage(tokenize(artist), candidateText)
+}
+
+// coverage returns the share of tokens that appear in the normalized text.
+func coverage(tokens []string, text string) float64 {
+	if len(tokens) == 0 {
+		return 0
+	}
+
+	found := 0
+	for _, token := range tokens {
+		if containsPhrase(text, token) {
+			found++
+		}
+	}
+
+	return float64(found) / float64(len(tokens))
+}
+
+// containsPhrase reports whether the normalized text contains the phrase as whole words.
+func containsPhrase(text string, phrase string) bool {
+	return strings.Contains(" "+text+" ", " "+phrase+" ")
+}
+
+func normalize(text string) string {
+	return strings.Join(tokenize(text), " ")
+}
+
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
@@ -0,0 +1,206 @@
+package trackmatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScore(t *testing.T) {
+	target := Target{
+		Title:    "Blinding Lights",
+		Artist:   "The Weeknd",
+		Duration: 200 * time.Second,
+	}
+
+	// the reference candidate matches the title, artist and duration exactly,
+	// every other case differs from it in a single way
+	reference := Candidate{
+		Title:    "The Weeknd - Blinding Lights",
+		Channel:  "The Weeknd",
+		Duration: 200 * time.Second,
+	}
+	referenceScore := Score(target, reference)
+
+	tests := []struct {
+		name      string
+		candidate Candidate
+		want      float64
+	}{
+		{
+			name:      "exact match",
+			candidate: reference,
+			want:      referenceScore,
+		},
+		{
+			name:      "duration off by half the tolerance",
+			candidate: Candidate{Title: reference.Title, Channel: reference.Channel, Duration: 215 * time.Second},
+			want:      referenceScore - durationWeight/2,
+		},
+		{
+			name:      "duration shorter by half the tolerance",
+			candidate: Candidate{Title: reference.Title, Channel: reference.Channel, Duration: 185 * time.Second},
+			want:      referenceScore - durationWeight/2,
+		},
+		{
+			name:      "duration outside the tolerance",
+			candidate: Candidate{Title: reference.Title, Channel: reference.Channel, Duration: 5 * time.Minute},
+			want:      referenceScore - durationWeight,
+		},
+		{
+			name:      "unknown duration",
+			candidate: Candidate{Title: reference.Title, Channel: reference.Channel},
+			want:      referenceScore - durationWeight,
+		},
+		{
+			name:      "live version",
+			candidate: Candidate{Title: reference.Title + " (Live)", Channel: reference.Channel, Duration: reference.Duration},
+			want:      referenceScore - unwantedPenalty,
+		},
+		{
+			name:      "cover",
+			candidate: Candidate{Title: reference.Title + " cover", Channel: reference.Channel, Duration: reference.Duration},
+			want:      referenceScore - unwantedPenalty,
+		},
+		{
+			name:      "sped up",
+			candidate: Candidate{Title: reference.Title + " [sped up]", Channel: reference.Channel, Duration: reference.Duration},
+			want:      referenceScore - unwantedPenalty,
+		},
+		{
+			name:      "reaction",
+			candidate: Candidate{Title: reference.Title + " REACTION", Channel: reference.Channel, Duration: reference.Duration},
+			want:      referenceScore - unwantedPenalty,
+		},
+		{
+			name:      "live and reaction stack",
+			candidate: Candidate{Title: reference.Title + " live reaction", Channel: reference.Channel, Duration: reference.Duration},
+			want:      referenceScore - 2*unwantedPenalty,
+		},
+		{
+			name:      "terms only match whole words",
+			candidate: Candidate{Title: reference.Title + " (Lively Edit)", Channel: reference.Channel, Duration: reference.Duration},
+			want:      referenceScore,
+		},
+		{
+			name:      "music video",
+			candidate: Candidate{Title: reference.Title + " (Official Video)", Channel: reference.Channel, Duration: reference.Duration},
+			want:      referenceScore - videoPenalty,
+		},
+		{
+			name:      "topic channel",
+			candidate: Candidate{Title: "Blinding Lights", Channel: "The Weeknd - Topic", Duration: reference.Duration},
+			want:      referenceScore + topicChannelBonus,
+		},
+		{
+			name:      "official audio",
+			candidate: Candidate{Title: reference.Title + " (Official Audio)", Channel: reference.Channel, Duration: reference.Duration},
+			want:      referenceScore + officialBonus,
+		},
+		{
+			name:      "artist only in a vevo channel name",
+			candidate: Candidate{Title: "Blinding Lights", Channel: "TheWeekndVEVO", Duration: reference.Duration},
+			want:      referenceScore,
+		},
+		{
+			name:      "wrong artist",
+			candidate: Candidate{Title: "Blinding Lights", Channel: "Someone Else", Duration: reference.Duration},
+			want:      referenceScore - artistWeight,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Score(target, tt.candidate); got != tt.want {
+				t.Errorf("Score() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreKeepsTermsTheTargetAskedFor(t *testing.T) {
+	target := Target{Title: "Blinding Lights (Live)", Artist: "The Weeknd"}
+	candidate := Candidate{Title: "The Weeknd - Blinding Lights (Live)", Channel: "The Weeknd"}
+
+	if got, want := Score(target, candidate), titleWeight+artistWeight; got != want {
+		t.Errorf("Score() = %v, want %v", got, want)
+	}
+}
+
+func TestBest(t *testing.T) {
+	target := Target{
+		Title:    "Blinding Lights",
+		Artist:   "The Weeknd",
+		Duration: 200 * time.Second,
+	}
+
+	tests := []struct {
+		name       string
+		candidates []Candidate
+		want       int
+	}{
+		{
+			name: "no candidates",
+			want: -1,
+		},
+		{
+			name: "single candidate",
+			candidates: []Candidate{
+				{Title: "Something Else", Channel: "Nobody"},
+			},
+			want: 0,
+		},
+		{
+			name: "prefers the studio recording over a live one",
+			candidates: []Candidate{
+				{Title: "The Weeknd - Blinding Lights (Live at the Grammys)", Channel: "The Weeknd", Duration: 200 * time.Second},
+				{Title: "Blinding Lights", Channel: "The Weeknd - Topic", Duration: 201 * time.Second},
+			},
+			want: 1,
+		},
+		{
+			name: "prefers the closer duration",
+			candidates: []Candidate{
+				{Title: "The Weeknd - Blinding Lights", Channel: "The Weeknd", Duration: 4 * time.Minute},
+				{Title: "The Weeknd - Blinding Lights", Channel: "The Weeknd", Duration: 202 * time.Second},
+			},
+			want: 1,
+		},
+		{
+			name: "ties keep the earliest candidate",
+			candidates: []Candidate{
+				{Title: "The Weeknd - Blinding Lights", Channel: "The Weeknd", Duration: 200 * time.Second},
+				{Title: "The Weeknd - Blinding Lights", Channel: "The Weeknd", Duration: 200 * time.Second},
+			},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Best(target, tt.candidates); got != tt.want {
+				t.Errorf("Best() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTrackName(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantTitle  string
+		wantArtist string
+	}{
+		{name: "Blinding Lights - The Weeknd", wantTitle: "Blinding Lights", wantArtist: "The Weeknd"},
+		{name: "Rock - Paper - Scissors - Band", wantTitle: "Rock - Paper - Scissors", wantArtist: "Band"},
+		{name: "Untitled", wantTitle: "Untitled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, artist := ParseTrackName(tt.name)
+			if title != tt.wantTitle || artist != tt.wantArtist {
+				t.Errorf("ParseTrackName() = (%q, %q), want (%q, %q)", title, artist, tt.wantTitle, tt.wantArtist)
+			}
+		})
+	}
+}
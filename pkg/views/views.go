@@ -20,6 +20,7 @@ type Config struct {
 	Components          *ComponentHandler         // Handles interactive message components.
 	Embeds              []*discordgo.MessageEmbed // List of embeds to send with the message.
 	Content             string                    // The message content.
+	Files               []*discordgo.File         // Files attached to the message, embeds can show them through attachment:// urls.
 	customConfigOptions                           // Struct embedding for additional options.
 }
 
@@ -70,21 +71,56 @@ func NewView(config *Config, opts ...ConfigOpts) *View {
 	}
 }
 
-// EditView updates the message components and embeds of an existing message.
+// EditView updates the message components, embeds and files of an existing message.
 // It uses ChannelMessageEditComplex to edit the message in the channel.
 func (v *View) EditView(viewConfig *Config, session *discordgo.Session) error {
-	if _, err := session.ChannelMessageEditComplex(&discordgo.MessageEdit{
-		ID:         v.MessageID,
-		Channel:    v.ChannelID,
-		Components: &viewConfig.Components.MessageComponents,
-		Embeds:     &viewConfig.Embeds,
-	}); err != nil {
+	files, attachments := v.pendingFiles(viewConfig.Files)
+
+	message, err := session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:          v.MessageID,
+		Channel:     v.ChannelID,
+		Components:  &viewConfig.Components.MessageComponents,
+		Embeds:      &viewConfig.Embeds,
+		Files:       files,
+		Attachments: &attachments,
+	})
+	if err != nil {
 		return fmt.Errorf("editing complex message: %w", err)
 	}
 
+	v.message = message
+
 	return nil
 }
 
+// pendingFiles splits the files into those that still need uploading and the attachments
+// the message already has under the same name, which are kept. Any other attachment is removed.
+func (v *View) pendingFiles(files []*discordgo.File) ([]*discordgo.File, []*discordgo.MessageAttachment) {
+	upload := []*discordgo.File{}
+	kept := []*discordgo.MessageAttachment{}
+
+	for _, file := range files {
+		attached := false
+
+		if v.message != nil {
+			for _, attachment := range v.message.Attachments {
+				if attachment.Filename == file.Name {
+					kept = append(kept, attachment)
+					attached = true
+
+					break
+				}
+			}
+		}
+
+		if !attached {
+			upload = append(upload, file)
+		}
+	}
+
+	return upload, kept
+}
+
 // DeleteView deletes the message from the channel using ChannelMessageDelete.
 func (v *View) DeleteView(session *discordgo.Session) error {
 	if err := session.ChannelMessageDelete(v.ChannelID, v.MessageID); err != nil {
@@ -113,6 +149,10 @@ func (v *View) SendView(interaction *discordgo.Interaction, session *discordgo.S
 		messageSendData.Components = config.Components.MessageComponents
 	}
 
+	if config.Files != nil {
+		messageSendData.Files = config.Files
+	}
+
 	// Assumes the interaction was deferred and sends a follow-up message.
 	message, err := session.FollowupMessageCreate(interaction, true, messageSendData)
 	if err != nil {
@@ -0,0 +1,57 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const suggestURL = "https://suggestqueries-clients6.youtube.com/complete/search"
+
+// Suggest returns the searches YouTube suggests for a partially typed query. Unlike the
+// Data API it costs no quota, so it's cheap enough to call on every keystroke.
+func Suggest(ctx context.Context, httpClient *http.Client, query string) ([]string, error) {
+	params := url.Values{}
+	params.Set("client", "firefox")
+	params.Set("ds", "yt")
+	params.Set("oe", "utf-8")
+	params.Set("q", query)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, suggestURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting suggestions: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("requesting suggestions: status %d", resp.StatusCode)
+	}
+
+	// the response is a list of the query followed by its suggestions
+	var body []json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding suggestions: %w", err)
+	}
+
+	if len(body) < 2 {
+		return nil, errors.New("suggestions response is missing its suggestions")
+	}
+
+	var suggestions []string
+	if err := json.Unmarshal(body[1], &suggestions); err != nil {
+		return nil, fmt.Errorf("decoding suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
@@ -4,31 +4,49 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
 	"github.com/TeddyKahwaji/spice-tunes-go/pkg/funcs"
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/ytdlp"
+	"github.com/wader/goutubedl"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	"google.golang.org/api/youtube/v3"
 )
 
 const (
-	YoutubeVideoBase = "https://www.youtube.com/watch?v="
+	YoutubeVideoBase    = "https://www.youtube.com/watch?v="
+	youtubePlaylistBase = "https://www.youtube.com/playlist?list="
+	// mixes go on for as long as they're played, only the start of one is queued
+	maxMixTracks = 50
+	// the Data API isn't tried again for this long once its quota ran out
+	quotaRetryInterval = time.Hour
+	// lookups through the Data API are quick, extracting through yt-dlp is considerably slower
+	apiTimeout = time.Second * 5
+	dlpTimeout = time.Minute
 )
 
+// SearchWrapper retrieves YouTube tracks through the Data API. Mixes, which the API
+// doesn't serve, and requests made while its quota is exhausted go through yt-dlp.
 type SearchWrapper struct {
 	ytPlaylistItemsService *youtube.PlaylistItemsService
 	ytVideoService         *youtube.VideosService
 	ytSearchService        *youtube.SearchService
 	ytPlaylistService      *youtube.PlaylistsService
+	dlpWrapper             *DLPWrapper
+	// quotaExhaustedAt holds when the API last reported its quota ran out, in unix nanoseconds
+	quotaExhaustedAt atomic.Int64
 }
 
-func NewYoutubeSearchWrapper(ctx context.Context, creds []byte) (*SearchWrapper, error) {
+func NewYoutubeSearchWrapper(ctx context.Context, creds []byte, httpClient *http.Client) (*SearchWrapper, error) {
 	service, err := youtube.NewService(ctx, option.WithCredentialsJSON(creds))
 	if err != nil {
 		return nil, fmt.Errorf("instantiating new service: %w", err)
@@ -39,6 +57,7 @@ func NewYoutubeSearchWrapper(ctx context.Context, creds []byte) (*SearchWrapper,
 		ytVideoService:         youtube.NewVideosService(service),
 		ytSearchService:        youtube.NewSearchService(service),
 		ytPlaylistService:      youtube.NewPlaylistsService(service),
+		dlpWrapper:             NewDLPWrapper(httpClient),
 	}, nil
 }
 
@@ -60,7 +79,144 @@ func extractYoutubeID(audioType audiotype.SupportedAudioType, fullURL string) (s
 	return "", errors.New("error: could not extract any ID")
 }
 
+// isQuotaError reports whether the Data API refused a request because the project's quota is used up.
+func isQuotaError(err error) bool {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	if apiErr.Code == http.StatusTooManyRequests {
+		return true
+	}
+
+	for _, item := range apiErr.Errors {
+		switch item.Reason {
+		case "quotaExceeded", "dailyLimitExceeded", "rateLimitExceeded":
+			return true
+		}
+	}
+
+	return false
+}
+
+// quotaExhausted reports whether requests should skip the API, as its quota ran out recently.
+func (yt *SearchWrapper) quotaExhausted() bool {
+	return time.Since(time.Unix(0, yt.quotaExhaustedAt.Load())) < quotaRetryInterval
+}
+
+// shouldFallBack reports whether the API failed because its quota ran out, remembering it if so.
+func (yt *SearchWrapper) shouldFallBack(err error) bool {
+	if !isQuotaError(err) {
+		return false
+	}
+
+	yt.quotaExhaustedAt.Store(time.Now().UnixNano())
+
+	return true
+}
+
+// RetrievalTimeout returns how long retrieving tracks of the audio type may take, which
+// is as long as yt-dlp needs whenever the request will be served by it.
+func (yt *SearchWrapper) RetrievalTimeout(audioType audiotype.SupportedAudioType) time.Duration {
+	if audioType == audiotype.YoutubeMix || yt.quotaExhausted() {
+		return dlpTimeout
+	}
+
+	return apiTimeout
+}
+
 func (yt *SearchWrapper) GetTracksData(ctx context.Context, audioType audiotype.SupportedAudioType, query string) (*audiotype.Data, error) {
+	if yt.quotaExhausted() {
+		return yt.dlpWrapper.GetTracksData(ctx, audioType, query)
+	}
+
+	trackData, err := yt.getTracksData(ctx, audioType, query)
+	if yt.shouldFallBack(err) {
+		// the deadline was set for the API, so yt-dlp is given its own
+		dlpCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), dlpTimeout)
+		defer cancel()
+
+		return yt.dlpWrapper.GetTracksData(dlpCtx, audioType, query)
+	}
+
+	return trackData, err
+}
+
+// SearchTracks returns up to limit videos matching the query, in the order YouTube ranks them.
+func (yt *SearchWrapper) SearchTracks(ctx context.Context, query string, limit int) ([]*audiotype.TrackData, error) {
+	if yt.quotaExhausted() {
+		return yt.dlpWrapper.SearchTracks(ctx, query, limit)
+	}
+
+	tracks, err := yt.searchTracks(ctx, query, limit)
+	if yt.shouldFallBack(err) {
+		return yt.dlpWrapper.SearchTracks(ctx, query, limit)
+	}
+
+	return tracks, err
+}
+
+func (yt *SearchWrapper) searchTracks(ctx context.Context, query string, limit int) ([]*audiotype.TrackData, error) {
+	const requesterNameKey = audiotype.ContextKey("requesterName")
+
+	requesterName, ok := ctx.Value(requesterNameKey).(string)
+	if !ok {
+		return nil, errors.New("context does not have proper authorization")
+	}
+
+	resp, err := yt.ytSearchService.List([]string{"snippet"}).
+		Q(query).
+		Type("video").
+		EventType("none").
+		MaxResults(int64(limit)).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, fmt.Errorf("searching youtube query: %w", err)
+	}
+
+	if len(resp.Items) == 0 {
+		return nil, audiotype.ErrSearchQueryNotFound
+	}
+
+	ids := funcs.Map(resp.Items, func(item *youtube.SearchResult) string {
+		return item.Id.VideoId
+	})
+
+	// search results don't carry durations, so the videos are listed as well
+	videos, err := yt.ytVideoService.List([]string{"snippet", "contentDetails"}).
+		Id(strings.Join(ids, ",")).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, fmt.Errorf("listing video ids: %w", err)
+	}
+
+	videosByID := make(map[string]*youtube.Video, len(videos.Items))
+	for _, video := range videos.Items {
+		videosByID[video.Id] = video
+	}
+
+	tracks := make([]*audiotype.TrackData, 0, len(ids))
+	for _, id := range ids {
+		video, ok := videosByID[id]
+		if !ok {
+			continue
+		}
+
+		track, err := newVideoTrackData(video, requesterName)
+		if err != nil {
+			return nil, err
+		}
+
+		tracks = append(tracks, track)
+	}
+
+	return tracks, nil
+}
+
+func (yt *SearchWrapper) getTracksData(ctx context.Context, audioType audiotype.SupportedAudioType, query string) (*audiotype.Data, error) {
 	var (
 		trackData *audiotype.Data
 		err       error
@@ -80,6 +236,10 @@ func (yt *SearchWrapper) GetTracksData(ctx context.Context, audioType audiotype.
 		return trackData, nil
 	}
 
+	if audioType == audiotype.YoutubeMix {
+		return yt.dlpWrapper.GetTracksData(ctx, audioType, query)
+	}
+
 	youtubeID, err := extractYoutubeID(audioType, query)
 	if err != nil {
 		return nil, fmt.Errorf("extracting youtube ID: %w", err)
@@ -96,6 +256,10 @@ func (yt *SearchWrapper) GetTracksData(ctx context.Context, audioType audiotype.
 		return nil, fmt.Errorf("getting track data: %w", err)
 	}
 
+	if audioType == audiotype.YoutubeSong {
+		trackData.Tracks[0].StartAt = audiotype.YoutubeTimestamp(query)
+	}
+
 	return trackData, nil
 }
 
@@ -109,14 +273,23 @@ func (yt *SearchWrapper) handleSingleTrack(requesterName string, ID string) (*au
 		return nil, fmt.Errorf("requesting single video: %w", err)
 	}
 
-	trackData := make([]*audiotype.TrackData, 0, 1)
-
 	if len(resp.Items) == 0 {
 		return nil, audiotype.ErrSearchQueryNotFound
 	}
 
-	item := resp.Items[0]
+	track, err := newVideoTrackData(resp.Items[0], requesterName)
+	if err != nil {
+		return nil, err
+	}
 
+	return &audiotype.Data{
+		Tracks: []*audiotype.TrackData{track},
+		Type:   audiotype.YoutubeSong,
+		ID:     ID,
+	}, nil
+}
+
+func newVideoTrackData(item *youtube.Video, requesterName string) (*audiotype.TrackData, error) {
 	var thumbnailURL string
 
 	if thumbnails := item.Snippet.Thumbnails; thumbnails != nil {
@@ -132,19 +305,13 @@ func (yt *SearchWrapper) handleSingleTrack(requesterName string, ID string) (*au
 		return nil, fmt.Errorf("retrieving duration of video: %w", err)
 	}
 
-	trackData = append(trackData, &audiotype.TrackData{
+	return &audiotype.TrackData{
 		TrackImageURL: thumbnailURL,
 		TrackName:     item.Snippet.Title,
-		Query:         YoutubeVideoBase + ID,
+		Query:         YoutubeVideoBase + item.Id,
 		Requester:     requesterName,
 		Duration:      duration,
-		ID:            ID,
-	})
-
-	return &audiotype.Data{
-		Tracks: trackData,
-		Type:   audiotype.YoutubeSong,
-		ID:     ID,
+		ID:            item.Id,
 	}, nil
 }
 
@@ -287,6 +454,171 @@ func (yt *SearchWrapper) handlePlaylist(requesterName string, ID string) (*audio
 	}, nil
 }
 
+// DLPWrapper retrieves YouTube tracks through yt-dlp, needing neither Data API
+// credentials nor quota at the cost of slower lookups.
+type DLPWrapper struct {
+	httpClient *http.Client
+}
+
+func NewDLPWrapper(httpClient *http.Client) *DLPWrapper {
+	return &DLPWrapper{
+		httpClient: httpClient,
+	}
+}
+
+// RetrievalTimeout returns how long retrieving tracks of the audio type may take.
+func (d *DLPWrapper) RetrievalTimeout(audiotype.SupportedAudioType) time.Duration {
+	return dlpTimeout
+}
+
+func (d *DLPWrapper) GetTracksData(ctx context.Context, audioType audiotype.SupportedAudioType, query string) (*audiotype.Data, error) {
+	var (
+		trackData *audiotype.Data
+		err       error
+	)
+
+	const requesterNameKey = audiotype.ContextKey("requesterName")
+
+	requesterName, ok := ctx.Value(requesterNameKey).(string)
+	if !ok {
+		return nil, errors.New("context does not have proper authorization")
+	}
+
+	switch audioType {
+	case audiotype.GenericSearch:
+		trackData, err = d.handleGenericSearch(ctx, requesterName, query)
+	case audiotype.YoutubeMix:
+		trackData, err = d.handleMix(ctx, requesterName, query)
+	case audiotype.YoutubeSong, audiotype.YoutubePlaylist:
+		var youtubeID string
+		if youtubeID, err = extractYoutubeID(audioType, query); err != nil {
+			return nil, fmt.Errorf("extracting youtube ID: %w", err)
+		}
+
+		if audioType == audiotype.YoutubeSong {
+			trackData, err = d.handleSingleTrack(ctx, requesterName, youtubeID)
+		} else {
+			trackData, err = d.handlePlaylist(ctx, requesterName, youtubeID)
+		}
+	default:
+		return nil, errors.New("audio type provided is not from a youtube source")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("getting track data: %w", err)
+	}
+
+	if audioType == audiotype.YoutubeSong {
+		trackData.Tracks[0].StartAt = audiotype.YoutubeTimestamp(query)
+	}
+
+	return trackData, nil
+}
+
+func (d *DLPWrapper) handleGenericSearch(ctx context.Context, requesterName string, query string) (*audiotype.Data, error) {
+	info, err := ytdlp.ExtractFlat(ctx, "ytsearch1:"+query)
+	if err != nil {
+		return nil, fmt.Errorf("searching youtube query: %w", err)
+	}
+
+	if len(info.Entries) == 0 {
+		return nil, audiotype.ErrSearchQueryNotFound
+	}
+
+	track := ytdlp.NewTrackData(info.Entries[0], requesterName)
+
+	return &audiotype.Data{
+		Tracks: []*audiotype.TrackData{track},
+		Type:   audiotype.YoutubeSong,
+		ID:     track.ID,
+	}, nil
+}
+
+// SearchTracks returns up to limit videos matching the query, in the order YouTube ranks them.
+func (d *DLPWrapper) SearchTracks(ctx context.Context, query string, limit int) ([]*audiotype.TrackData, error) {
+	const requesterNameKey = audiotype.ContextKey("requesterName")
+
+	requesterName, ok := ctx.Value(requesterNameKey).(string)
+	if !ok {
+		return nil, errors.New("context does not have proper authorization")
+	}
+
+	info, err := ytdlp.ExtractFlat(ctx, fmt.Sprintf("ytsearch%d:%s", limit, query))
+	if err != nil {
+		return nil, fmt.Errorf("searching youtube query: %w", err)
+	}
+
+	if len(info.Entries) == 0 {
+		return nil, audiotype.ErrSearchQueryNotFound
+	}
+
+	return funcs.Map(info.Entries, func(entry goutubedl.Info) *audiotype.TrackData {
+		return ytdlp.NewTrackData(entry, requesterName)
+	}), nil
+}
+
+func (d *DLPWrapper) handleSingleTrack(ctx context.Context, requesterName string, ID string) (*audiotype.Data, error) {
+	info, err := ytdlp.Extract(ctx, d.httpClient, YoutubeVideoBase+ID, goutubedl.TypeSingle)
+	if err != nil {
+		return nil, fmt.Errorf("extracting video: %w", err)
+	}
+
+	return &audiotype.Data{
+		Tracks: []*audiotype.TrackData{ytdlp.NewTrackData(info, requesterName)},
+		Type:   audiotype.YoutubeSong,
+		ID:     ID,
+	}, nil
+}
+
+func (d *DLPWrapper) handlePlaylist(ctx context.Context, requesterName string, ID string) (*audiotype.Data, error) {
+	info, err := ytdlp.ExtractFlat(ctx, youtubePlaylistBase+ID)
+	if err != nil {
+		return nil, fmt.Errorf("extracting playlist: %w", err)
+	}
+
+	return newListData(info, info.Entries, requesterName, audiotype.YoutubePlaylist, ID)
+}
+
+// handleMix extracts a mix, which the Data API refuses as it's generated for a viewer.
+func (d *DLPWrapper) handleMix(ctx context.Context, requesterName string, query string) (*audiotype.Data, error) {
+	// a mix can only be extracted from the video it was started from, which
+	// the list id ends with when the link doesn't name it
+	listID := audiotype.YoutubePlaylistRegex.FindStringSubmatch(query)[1]
+	if !audiotype.YoutubeVideoRegex.MatchString(query) && len(listID) == len("RD")+11 {
+		query = YoutubeVideoBase + listID[len("RD"):] + "&list=" + listID
+	}
+
+	info, err := ytdlp.ExtractFlat(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("extracting mix: %w", err)
+	}
+
+	entries := info.Entries
+	if len(entries) > maxMixTracks {
+		entries = entries[:maxMixTracks]
+	}
+
+	return newListData(info, entries, requesterName, audiotype.YoutubeMix, listID)
+}
+
+func newListData(info goutubedl.Info, entries []goutubedl.Info, requesterName string, audioType audiotype.SupportedAudioType, ID string) (*audiotype.Data, error) {
+	if len(entries) == 0 {
+		return nil, audiotype.ErrSearchQueryNotFound
+	}
+
+	trackData := make([]*audiotype.TrackData, 0, len(entries))
+	for _, entry := range entries {
+		trackData = append(trackData, ytdlp.NewTrackData(entry, requesterName))
+	}
+
+	return &audiotype.Data{
+		Tracks:       trackData,
+		Type:         audioType,
+		PlaylistData: ytdlp.NewPlaylistData(info, trackData),
+		ID:           ID,
+	}, nil
+}
+
 func parseISO8601Duration(isoDuration string) (time.Duration, error) {
 	re := regexp.MustCompile(`PT(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?`)
 
@@ -0,0 +1,229 @@
+package ytdlp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/TeddyKahwaji/spice-tunes-go/pkg/audiotype"
+	"github.com/wader/goutubedl"
+)
+
+var ErrExtractorNotAllowed = errors.New("extractor is not allowed in this deployment")
+
+// ExtractorFilter restricts the yt-dlp extractors the generic source may use.
+// Extractors are matched case insensitively by their base name, so "soundcloud"
+// also covers "soundcloud:set". When Allow is empty every extractor that isn't
+// denied is permitted.
+type ExtractorFilter struct {
+	Allow []string
+	Deny  []string
+}
+
+// ParseExtractorList parses a comma separated list of extractor names.
+func ParseExtractorList(list string) []string {
+	extractors := []string{}
+
+	for _, extractor := range strings.Split(list, ",") {
+		if extractor = strings.TrimSpace(extractor); extractor != "" {
+			extractors = append(extractors, extractor)
+		}
+	}
+
+	return extractors
+}
+
+func extractorBaseName(extractor string) string {
+	base, _, _ := strings.Cut(extractor, ":")
+
+	return strings.ToLower(base)
+}
+
+func (f ExtractorFilter) Permits(extractor string) bool {
+	base := extractorBaseName(extractor)
+
+	for _, denied := range f.Deny {
+		if extractorBaseName(denied) == base {
+			return false
+		}
+	}
+
+	if len(f.Allow) == 0 {
+		return true
+	}
+
+	for _, allowed := range f.Allow {
+		if extractorBaseName(allowed) == base {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check returns ErrExtractorNotAllowed unless the extractor of the info and those of
+// all its entries are permitted, playlists may link to tracks from other sites.
+func (f ExtractorFilter) Check(info goutubedl.Info) error {
+	if !f.Permits(info.Extractor) {
+		return fmt.Errorf("%w: %s", ErrExtractorNotAllowed, info.Extractor)
+	}
+
+	for _, entry := range info.Entries {
+		if !f.Permits(entry.Extractor) {
+			return fmt.Errorf("%w: %s", ErrExtractorNotAllowed, entry.Extractor)
+		}
+	}
+
+	return nil
+}
+
+// Extract retrieves the metadata of the url with yt-dlp without downloading it.
+func Extract(ctx context.Context, httpClient *http.Client, url string, infoType goutubedl.Type) (goutubedl.Info, error) {
+	result, err := goutubedl.New(ctx, url, goutubedl.Options{
+		Type:       infoType,
+		HTTPClient: httpClient,
+	})
+	if err != nil {
+		// yt-dlp reports urls none of its extractors understand this way
+		if strings.Contains(err.Error(), "Unsupported URL") {
+			return goutubedl.Info{}, audiotype.ErrUnsupportedAudioType
+		}
+
+		return goutubedl.Info{}, fmt.Errorf("extracting metadata: %w", err)
+	}
+
+	return result.Info, nil
+}
+
+// ExtractFlat lists the entries of a playlist or search without resolving each of
+// them, which takes a fraction of the time for long lists. Entries only carry basic
+// metadata, their thumbnail is taken from the largest one listed.
+func ExtractFlat(ctx context.Context, url string) (goutubedl.Info, error) {
+	// goutubedl has no way to pass --flat-playlist, so yt-dlp is run directly
+	cmd := exec.CommandContext(ctx, goutubedl.ProbePath(), "--flat-playlist", "--dump-single-json", "--no-warnings", "--", url)
+
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && strings.Contains(string(exitErr.Stderr), "Unsupported URL") {
+			return goutubedl.Info{}, audiotype.ErrUnsupportedAudioType
+		}
+
+		return goutubedl.Info{}, fmt.Errorf("extracting flat metadata: %w", err)
+	}
+
+	var info goutubedl.Info
+	if err := json.Unmarshal(output, &info); err != nil {
+		return goutubedl.Info{}, fmt.Errorf("decoding flat metadata: %w", err)
+	}
+
+	for i, entry := range info.Entries {
+		if entry.Thumbnail == "" && len(entry.Thumbnails) > 0 {
+			info.Entries[i].Thumbnail = entry.Thumbnails[len(entry.Thumbnails)-1].URL
+		}
+	}
+
+	return info, nil
+}
+
+// NewTrackData builds track data from the metadata of a single track.
+func NewTrackData(info goutubedl.Info, requesterName string) *audiotype.TrackData {
+	query := info.WebpageURL
+	if query == "" {
+		query = info.URL
+	}
+
+	return &audiotype.TrackData{
+		TrackName:     info.Title,
+		TrackImageURL: info.Thumbnail,
+		Query:         query,
+		Requester:     requesterName,
+		Duration:      time.Duration(info.Duration * float64(time.Second)),
+		ID:            info.ID,
+	}
+}
+
+// NewPlaylistData builds playlist data from the metadata of a playlist, playlists
+// without their own artwork use the artwork of their first track.
+func NewPlaylistData(info goutubedl.Info, tracks []*audiotype.TrackData) *audiotype.PlaylistData {
+	playlistData := &audiotype.PlaylistData{
+		PlaylistName:     info.Title,
+		PlaylistImageURL: info.Thumbnail,
+	}
+
+	if playlistData.PlaylistImageURL == "" && len(tracks) > 0 {
+		playlistData.PlaylistImageURL = tracks[0].TrackImageURL
+	}
+
+	return playlistData
+}
+
+// GenericWrapper retrieves tracks from any url one of the permitted yt-dlp extractors supports.
+type GenericWrapper struct {
+	httpClient *http.Client
+	filter     ExtractorFilter
+}
+
+func NewGenericWrapper(httpClient *http.Client, filter ExtractorFilter) *GenericWrapper {
+	return &GenericWrapper{
+		httpClient: httpClient,
+		filter:     filter,
+	}
+}
+
+// CheckExtractors returns ErrExtractorNotAllowed when yt-dlp extracted the info with
+// an extractor the deployment doesn't permit.
+func (g *GenericWrapper) CheckExtractors(info goutubedl.Info) error {
+	return g.filter.Check(info)
+}
+
+func (g *GenericWrapper) GetTracksData(ctx context.Context, audioType audiotype.SupportedAudioType, query string) (*audiotype.Data, error) {
+	const requesterNameKey = audiotype.ContextKey("requesterName")
+
+	requesterName, ok := ctx.Value(requesterNameKey).(string)
+	if !ok {
+		return nil, errors.New("context does not have proper authorization")
+	}
+
+	if audioType != audiotype.GenericURL {
+		return nil, errors.New("audio type provided is not a generic url")
+	}
+
+	info, err := Extract(ctx, g.httpClient, query, goutubedl.TypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.filter.Check(info); err != nil {
+		return nil, err
+	}
+
+	if info.Type != "playlist" && len(info.Entries) == 0 {
+		return &audiotype.Data{
+			Tracks: []*audiotype.TrackData{NewTrackData(info, requesterName)},
+			Type:   audiotype.GenericURL,
+			ID:     info.ID,
+		}, nil
+	}
+
+	if len(info.Entries) == 0 {
+		return nil, audiotype.ErrSearchQueryNotFound
+	}
+
+	trackData := make([]*audiotype.TrackData, 0, len(info.Entries))
+	for _, entry := range info.Entries {
+		trackData = append(trackData, NewTrackData(entry, requesterName))
+	}
+
+	return &audiotype.Data{
+		Tracks:       trackData,
+		Type:         audiotype.GenericURL,
+		PlaylistData: NewPlaylistData(info, trackData),
+		ID:           info.ID,
+	}, nil
+}
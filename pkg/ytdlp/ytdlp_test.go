@@ -0,0 +1,62 @@
+package ytdlp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/wader/goutubedl"
+)
+
+func TestExtractorFilterCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  ExtractorFilter
+		info    goutubedl.Info
+		wantErr bool
+	}{
+		{
+			name:   "no restrictions",
+			filter: ExtractorFilter{},
+			info:   goutubedl.Info{Extractor: "vimeo"},
+		},
+		{
+			name:   "allowed base name covers sub extractors",
+			filter: ExtractorFilter{Allow: []string{"Bandcamp"}},
+			info: goutubedl.Info{
+				Extractor: "bandcamp:album",
+				Entries:   []goutubedl.Info{{Extractor: "bandcamp"}, {Extractor: "Bandcamp"}},
+			},
+		},
+		{
+			name:    "denied extractor",
+			filter:  ExtractorFilter{Allow: []string{"vimeo"}, Deny: []string{"vimeo"}},
+			info:    goutubedl.Info{Extractor: "vimeo"},
+			wantErr: true,
+		},
+		{
+			name:    "playlist linking to a denied extractor",
+			filter:  ExtractorFilter{Deny: []string{"youtube"}},
+			info:    goutubedl.Info{Extractor: "generic", Entries: []goutubedl.Info{{Extractor: "vimeo"}, {Extractor: "youtube"}}},
+			wantErr: true,
+		},
+		{
+			name:    "playlist linking to an extractor outside the allow list",
+			filter:  ExtractorFilter{Allow: []string{"mixcloud"}},
+			info:    goutubedl.Info{Extractor: "mixcloud:playlist", Entries: []goutubedl.Info{{Extractor: "soundcloud"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Check(tt.info)
+			if tt.wantErr != errors.Is(err, ErrExtractorNotAllowed) {
+				t.Errorf("Check() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && err != nil {
+				t.Errorf("Check() error = %v", err)
+			}
+		})
+	}
+}